@@ -25,7 +25,7 @@ const docTemplate = `{
     "paths": {
         "/events": {
             "get": {
-                "description": "Retrieve a list of all events with their details",
+                "description": "Retrieve a list of all events with their details. Draft events are never listed; the events of a series, drafts included, are listed under the series.",
                 "consumes": [
                     "application/json"
                 ],
@@ -36,6 +36,51 @@ const docTemplate = `{
                     "Events"
                 ],
                 "summary": "Get all events",
+                "parameters": [
+                    {
+                        "enum": [
+                            "PUBLISHED",
+                            "ON_SALE",
+                            "SOLD_OUT",
+                            "CANCELLED",
+                            "COMPLETED"
+                        ],
+                        "type": "string",
+                        "description": "Only return events with this status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return events on this calendar day in their local timezone (YYYY-MM-DD)",
+                        "name": "day",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return events in this category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return events with this tag",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return events within radius_km of this point (lat,lng), sorted by distance",
+                        "name": "near",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Search radius in kilometers when near is set (default 25)",
+                        "name": "radius_km",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -46,6 +91,12 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ValidationErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -55,7 +106,7 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Create a new event with the provided details",
+                "description": "Create a new event with the provided details. The date is either an RFC3339 timestamp or a local date-time in the event's timezone. Events default to UTC.",
                 "consumes": [
                     "application/json"
                 ],
@@ -99,9 +150,55 @@ const docTemplate = `{
                 }
             }
         },
-        "/events/{eventId}/tickets": {
+        "/events/import": {
+            "post": {
+                "description": "Restore an event archive created by the export endpoint. The event, its tickets and its reservations are created with new IDs, and their relationships are kept. Ticket availability and remaining general admission places are recomputed from the reservations; archives whose reservations hold more places than a ticket has are rejected. The imported event is not attached to any series.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Import an event archive",
+                "parameters": [
+                    {
+                        "description": "Event archive",
+                        "name": "archive",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.EventArchive"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Event"
+                        }
+                    },
+                    "400": {
+                        "description": "Unsupported archive version / Invalid archive",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/search": {
             "get": {
-                "description": "Retrieve a list of all tickets for an event with their details",
+                "description": "Full-text search over event names, tags, categories and venues. Results are ranked by relevance and come with counts by category, venue and local month. Draft events are never returned.",
                 "consumes": [
                     "application/json"
                 ],
@@ -109,32 +206,53 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tickets"
+                    "Events"
                 ],
-                "summary": "Get all tickets for an event",
+                "summary": "Search events",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Event ID",
-                        "name": "eventId",
-                        "in": "path",
+                        "description": "Search text",
+                        "name": "q",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return events in this category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return events at this venue",
+                        "name": "venue",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only return events in this local month (YYYY-MM)",
+                        "name": "month",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of results (default 20, max 100)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Ticket"
-                            }
+                            "$ref": "#/definitions/models.EventSearchResult"
                         }
                     },
-                    "404": {
-                        "description": "Event not found",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
+                            "$ref": "#/definitions/responses.ValidationErrorResponse"
                         }
                     },
                     "500": {
@@ -144,9 +262,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/events/{eventId}/checkins": {
             "post": {
-                "description": "Create a ticket with the provided details",
+                "description": "Validate a scanned ticket token and admit its holder. Each ticket can only be checked in once.",
                 "consumes": [
                     "application/json"
                 ],
@@ -154,9 +274,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tickets"
+                    "Check-ins"
                 ],
-                "summary": "Create a ticket",
+                "summary": "Check in a ticket",
                 "parameters": [
                     {
                         "type": "string",
@@ -166,12 +286,12 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Ticket details",
-                        "name": "ticket",
+                        "description": "Scanned ticket token",
+                        "name": "checkin",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/requests.CreateTicketRequest"
+                            "$ref": "#/definitions/requests.CreateCheckInRequest"
                         }
                     }
                 ],
@@ -179,7 +299,7 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.Ticket"
+                            "$ref": "#/definitions/models.Reservation"
                         }
                     },
                     "400": {
@@ -189,15 +309,15 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Event not found",
+                        "description": "Event not found / Invalid ticket token",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "409": {
-                        "description": "Seat number is already taken",
+                        "description": "Ticket has already been checked in",
                         "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
+                            "$ref": "#/definitions/responses.AlreadyCheckedInResponse"
                         }
                     },
                     "500": {
@@ -209,9 +329,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/events/{eventId}/tickets/{id}": {
+        "/events/{eventId}/checkins/stats": {
             "get": {
-                "description": "Get details of a ticket by its ID",
+                "description": "Get the live number of reserved tickets and admitted attendees for an event",
                 "consumes": [
                     "application/json"
                 ],
@@ -219,9 +339,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tickets"
+                    "Check-ins"
                 ],
-                "summary": "Get ticket by ID",
+                "summary": "Get check-in counts",
                 "parameters": [
                     {
                         "type": "string",
@@ -229,24 +349,17 @@ const docTemplate = `{
                         "name": "eventId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Ticket ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Ticket"
+                            "$ref": "#/definitions/models.CheckInStats"
                         }
                     },
                     "404": {
-                        "description": "Ticket not found for the given event",
+                        "description": "Event not found",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
@@ -258,9 +371,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a ticket by its ID",
+            }
+        },
+        "/events/{eventId}/listings": {
+            "get": {
+                "description": "Retrieve all active resale listings for an event",
                 "consumes": [
                     "application/json"
                 ],
@@ -268,9 +383,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tickets"
+                    "Resale"
                 ],
-                "summary": "Delete a ticket",
+                "summary": "Get resale listings for an event",
                 "parameters": [
                     {
                         "type": "string",
@@ -278,21 +393,20 @@ const docTemplate = `{
                         "name": "eventId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Ticket ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Listing"
+                            }
+                        }
                     },
                     "404": {
-                        "description": "Ticket not found for the given event",
+                        "description": "Event not found",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
@@ -305,8 +419,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "patch": {
-                "description": "Update the details of an existing ticket by its ID",
+            "post": {
+                "description": "List an active reservation for resale. The price may not exceed the event's resale price cap, a percentage of the ticket's face value.",
                 "consumes": [
                     "application/json"
                 ],
@@ -314,9 +428,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tickets"
+                    "Resale"
                 ],
-                "summary": "Update an existing ticket",
+                "summary": "List a ticket for resale",
                 "parameters": [
                     {
                         "type": "string",
@@ -326,27 +440,20 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Ticket ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Updated ticket details",
-                        "name": "ticket",
+                        "description": "Listing details",
+                        "name": "listing",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/requests.UpdateTicketRequest"
+                            "$ref": "#/definitions/requests.CreateListingRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.Ticket"
+                            "$ref": "#/definitions/models.Listing"
                         }
                     },
                     "400": {
@@ -355,8 +462,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/responses.ValidationErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Invalid ticket token",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Ticket not found for the given event",
+                        "description": "Event/ticket/reservation not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Ticket is already listed / Reservation is not active / Event is not on sale / General admission tickets cannot be resold",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
@@ -370,9 +489,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/events/{eventId}/tickets/{ticketId}/reservation": {
+        "/events/{eventId}/listings/{id}": {
             "get": {
-                "description": "Get reservation details for a ticket",
+                "description": "Get details of a resale listing by its ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -380,9 +499,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Reservations"
+                    "Resale"
                 ],
-                "summary": "Get reservation",
+                "summary": "Get resale listing by ID",
                 "parameters": [
                     {
                         "type": "string",
@@ -393,8 +512,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Ticket ID",
-                        "name": "ticketId",
+                        "description": "Listing ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -403,11 +522,11 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Reservation"
+                            "$ref": "#/definitions/models.Listing"
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Listing not found for the given event",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
@@ -420,8 +539,8 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
-                "description": "Create a reservation for a ticket",
+            "delete": {
+                "description": "Withdraw an active resale listing. Only the seller, proven by their ticket token, can cancel it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -429,9 +548,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Reservations"
+                    "Resale"
                 ],
-                "summary": "Create a reservation",
+                "summary": "Cancel a resale listing",
                 "parameters": [
                     {
                         "type": "string",
@@ -442,27 +561,24 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Ticket ID",
-                        "name": "ticketId",
+                        "description": "Listing ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Reservation details",
-                        "name": "reservation",
+                        "description": "Seller's ticket token",
+                        "name": "listing",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/requests.CreateReservationRequest"
+                            "$ref": "#/definitions/requests.CancelListingRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/models.Reservation"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "400": {
                         "description": "Bad Request",
@@ -470,14 +586,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/responses.ValidationErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Invalid ticket token",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Ticket/event not found",
+                        "description": "Listing not found for the given event",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "409": {
-                        "description": "Ticket is already reserved / Event date has already passed",
+                        "description": "Listing is no longer available",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
@@ -489,9 +611,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Cancel and delete an existing reservation",
+            }
+        },
+        "/events/{eventId}/listings/{id}/purchase": {
+            "post": {
+                "description": "Buy a ticket listed for resale. The reservation moves to the buyer with a new ticket token, and a payout record is created for the seller.",
                 "consumes": [
                     "application/json"
                 ],
@@ -499,9 +623,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Reservations"
+                    "Resale"
                 ],
-                "summary": "Cancel a reservation",
+                "summary": "Purchase a resale listing",
                 "parameters": [
                     {
                         "type": "string",
@@ -512,18 +636,42 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Ticket ID",
-                        "name": "ticketId",
+                        "description": "Listing ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Buyer details",
+                        "name": "purchase",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requests.PurchaseListingRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Reservation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ValidationErrorResponse"
+                        }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Event/listing not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Listing is no longer available / Event is not on sale",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
@@ -535,9 +683,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Update details of an existing reservation",
+            }
+        },
+        "/events/{eventId}/payouts": {
+            "get": {
+                "description": "Retrieve the payout records owed to sellers for completed resales of an event",
                 "consumes": [
                     "application/json"
                 ],
@@ -545,9 +695,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Reservations"
+                    "Resale"
                 ],
-                "summary": "Update a reservation",
+                "summary": "Get seller payouts for an event",
                 "parameters": [
                     {
                         "type": "string",
@@ -555,21 +705,103 @@ const docTemplate = `{
                         "name": "eventId",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Payout"
+                            }
+                        }
                     },
+                    "404": {
+                        "description": "Event not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{eventId}/queue": {
+            "get": {
+                "description": "Get the settings and progress of the virtual queue of an event, including the number of people still waiting.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Queue"
+                ],
+                "summary": "Get the virtual queue of an event",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Ticket ID",
-                        "name": "ticketId",
+                        "description": "Event ID",
+                        "name": "eventId",
                         "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.EventQueue"
+                        }
+                    },
+                    "404": {
+                        "description": "Queue not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Enable, disable or tune the virtual queue of an event. While the queue is enabled, reservations for the event require an admitted queue token. Every interval_seconds the next batch_size positions in the queue are admitted. Disabling the queue lets every request through again; positions are kept if it is enabled later.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Queue"
+                ],
+                "summary": "Configure the virtual queue of an event",
+                "parameters": [
                     {
-                        "description": "Updated reservation details",
-                        "name": "reservation",
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Queue settings",
+                        "name": "queue",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/requests.UpdateReservationRequest"
+                            "$ref": "#/definitions/requests.ConfigureQueueRequest"
                         }
                     }
                 ],
@@ -577,7 +809,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.Reservation"
+                            "$ref": "#/definitions/models.EventQueue"
                         }
                     },
                     "400": {
@@ -587,7 +819,7 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Event not found",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
@@ -601,9 +833,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/events/{id}": {
-            "get": {
-                "description": "Get details of an event by its ID",
+        "/events/{eventId}/queue/join": {
+            "post": {
+                "description": "Take the next position in the virtual queue of an event. The returned token identifies the position; send it in the X-Queue-Token header to check the position and, once admitted, to make reservations. Admission lasts for a limited time, shown in expires_at.",
                 "consumes": [
                     "application/json"
                 ],
@@ -611,33 +843,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Events"
+                    "Queue"
                 ],
-                "summary": "Get event by ID",
+                "summary": "Join the virtual queue of an event",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Event ID",
-                        "name": "id",
+                        "name": "eventId",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.Event"
+                            "$ref": "#/definitions/models.QueuePosition"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Event date has already passed / Event is cancelled",
                         "schema": {
-                            "$ref": "#/definitions/responses.ValidationErrorResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Event not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Event has no enabled queue",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests, retry after the number of seconds in the Retry-After header",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
@@ -649,9 +893,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete an event by its ID",
+            }
+        },
+        "/events/{eventId}/queue/position": {
+            "get": {
+                "description": "Get the position, the number of people ahead and the estimated wait of a queue token. Clients are expected to poll this endpoint every few seconds, or to use the stream endpoint instead.",
                 "consumes": [
                     "application/json"
                 ],
@@ -659,24 +905,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Events"
+                    "Queue"
                 ],
-                "summary": "Delete an event",
+                "summary": "Get a position in the virtual queue",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Event ID",
-                        "name": "id",
+                        "name": "eventId",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Queue token",
+                        "name": "X-Queue-Token",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Queue token, for clients that cannot set headers",
+                        "name": "token",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Deleted"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.QueuePosition"
+                        }
+                    },
+                    "401": {
+                        "description": "Queue token is required / Invalid queue token",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Queue not found",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
@@ -688,9 +955,70 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Update the details of an existing event by its ID",
+            }
+        },
+        "/events/{eventId}/queue/position/stream": {
+            "get": {
+                "description": "Stream the position of a queue token as server-sent events. A \"position\" event carrying a models.QueuePosition is sent every few seconds until the token is admitted. The stream is closed periodically; EventSource clients reconnect on their own.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Queue"
+                ],
+                "summary": "Stream a position in the virtual queue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Queue token",
+                        "name": "X-Queue-Token",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Queue token, for clients that cannot set headers",
+                        "name": "token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stream of position events",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Queue token is required / Invalid queue token",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Queue not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{eventId}/reservations/best-available": {
+            "post": {
+                "description": "Reserve a number of available tickets chosen by the system, all for the same customer. Consecutive seats in the same row are preferred, and among those the most expensive block within the price ceiling; seats are placed by their section, row and seat. If no such block exists, the closest seats in one row are chosen, then the best tickets overall; adjacent in the response tells which happened. The section limits the search to tickets in that section or category. When the event's seating rules forbid single-seat gaps, blocks that would leave one are skipped. Either every ticket is reserved or none is. Rate limits and the event's virtual queue apply as for single reservations.",
                 "consumes": [
                     "application/json"
                 ],
@@ -698,32 +1026,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Events"
+                    "Reservations"
                 ],
-                "summary": "Update an existing event",
+                "summary": "Reserve the best available tickets",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "Event ID",
-                        "name": "id",
+                        "name": "eventId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated event details",
-                        "name": "event",
+                        "description": "Reservation details",
+                        "name": "reservation",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/requests.UpdateEventRequest"
+                            "$ref": "#/definitions/requests.BestAvailableRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Admitted queue token, required while the event's queue is enabled",
+                        "name": "X-Queue-Token",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/models.Event"
+                            "$ref": "#/definitions/models.BestAvailableReservation"
                         }
                     },
                     "400": {
@@ -732,8 +1066,32 @@ const docTemplate = `{
                             "$ref": "#/definitions/responses.ValidationErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Queue token is required / Invalid queue token",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Queue token has not been admitted yet / Queue admission has expired",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Event not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Not enough available tickets / Event is not on sale / Reservation would leave a single empty seat",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests, retry after the number of seconds in the Retry-After header",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
@@ -746,151 +1104,2975 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.Event": {
-            "type": "object",
-            "properties": {
-                "id": {
-                    "type": "string",
-                    "x-order": "0",
-                    "example": "68f0c6a8f5673dc0ec646731"
-                },
-                "name": {
-                    "type": "string",
-                    "x-order": "1",
-                    "example": "FORMULA 1 ETIHAD AIRWAYS ABU DHABI GRAND PRIX 2025"
-                },
-                "date": {
-                    "type": "string",
-                    "x-order": "2",
-                    "example": "2025-12-07T19:00:00Z"
-                },
-                "venue": {
-                    "type": "string",
-                    "x-order": "3",
-                    "example": "YTÜ Davutpaşa Tarihi Hamam"
-                }
-            }
         },
-        "models.Reservation": {
-            "type": "object",
-            "properties": {
-                "id": {
-                    "type": "string",
-                    "x-order": "0",
-                    "example": "68f4fea9990e605d6589b5f3"
-                },
-                "event_id": {
-                    "type": "string",
-                    "x-order": "1",
-                    "example": "68f0c6a8f5673dc0ec646731"
-                },
-                "ticket_id": {
-                    "type": "string",
-                    "x-order": "2",
-                    "example": "68f2ab0516a352dc8f40c543"
-                },
-                "customer_name": {
-                    "type": "string",
-                    "x-order": "3",
-                    "example": "Lewis Hamilton"
+        "/events/{eventId}/tickets": {
+            "get": {
+                "description": "Retrieve a list of all tickets for an event with their details",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tickets"
+                ],
+                "summary": "Get all tickets for an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Ticket"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Event not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a ticket with the provided details. A ticket with a capacity is a general admission ticket: it has no seat and can be reserved until its places run out.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tickets"
+                ],
+                "summary": "Create a ticket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Ticket details",
+                        "name": "ticket",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requests.CreateTicketRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Ticket"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Event not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Seat number is already taken / Event has been cancelled",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{eventId}/tickets/import": {
+            "post": {
+                "description": "Create many tickets at once from CSV rows of seat_number,price[,category[,section[,row,seat]]]. Row and seat default to the letters and number of the seat number, such as A and 12 for A12. A header row is optional. The CSV is sent either as the request body or as the \"file\" field of a multipart form. Every row is validated like a single ticket; if any row is invalid nothing is created and the per-row errors are returned. With dry_run=true the rows are only checked.",
+                "consumes": [
+                    "text/csv",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tickets"
+                ],
+                "summary": "Import tickets from CSV",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only validate the rows without creating tickets",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "file",
+                        "description": "CSV file",
+                        "name": "file",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Dry run report",
+                        "schema": {
+                            "$ref": "#/definitions/responses.TicketImportResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/responses.TicketImportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Some rows are invalid",
+                        "schema": {
+                            "$ref": "#/definitions/responses.TicketImportResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Event not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Event has been cancelled or a seat was taken concurrently",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{eventId}/tickets/{id}": {
+            "get": {
+                "description": "Get details of a ticket by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tickets"
+                ],
+                "summary": "Get ticket by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ticket ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Ticket"
+                        }
+                    },
+                    "404": {
+                        "description": "Ticket not found for the given event",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a ticket by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tickets"
+                ],
+                "summary": "Delete a ticket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ticket ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Ticket not found for the given event",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Update the details of an existing ticket by its ID. The capacity of a general admission ticket cannot drop below the places already sold, and seated tickets have no capacity.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tickets"
+                ],
+                "summary": "Update an existing ticket",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ticket ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated ticket details",
+                        "name": "ticket",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requests.UpdateTicketRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Ticket"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Ticket not found for the given event",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Seat number is already taken / Capacity is below the places already sold",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{eventId}/tickets/{ticketId}/reservation": {
+            "get": {
+                "description": "Get reservation details for a ticket. Only the holder can read a reservation, by presenting its token; general admission tickets have a reservation per buyer, and the token selects it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reservations"
+                ],
+                "summary": "Get reservation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ticket ID",
+                        "name": "ticketId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reservation token",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Reservation"
+                        }
+                    },
+                    "400": {
+                        "description": "Reservation token is required",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a reservation for a ticket. General admission tickets can be reserved several places at a time with quantity; each reservation gets its own token, which is needed to view, change or cancel it. Reservation attempts are rate limited per client address and per customer name; the RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset headers describe the remaining quota. While the event's virtual queue is enabled, an admitted queue token is required in the X-Queue-Token header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reservations"
+                ],
+                "summary": "Create a reservation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ticket ID",
+                        "name": "ticketId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reservation details",
+                        "name": "reservation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requests.CreateReservationRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Admitted queue token, required while the event's queue is enabled",
+                        "name": "X-Queue-Token",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Reservation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Queue token is required / Invalid queue token",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Queue token has not been admitted yet / Queue admission has expired",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Ticket/event not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Ticket is already reserved / Not enough places remaining / Event is not on sale / Reservation would leave a single empty seat",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests, retry after the number of seconds in the Retry-After header",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Cancel an existing reservation. The ticket becomes available again and the cancelled reservation is kept for reporting. The holder proves ownership with the reservation token. Cancelling a general admission reservation returns its places to the ticket's capacity.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reservations"
+                ],
+                "summary": "Cancel a reservation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ticket ID",
+                        "name": "ticketId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reservation token",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Reservation token is required",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Reservation has already been checked in",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{eventId}/tickets/{ticketId}/reservation/transfer": {
+            "post": {
+                "description": "Start transferring a reservation to another customer. The holder proves ownership with their ticket token and receives a one-time code to hand over to the recipient. Starting a new transfer replaces any pending one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reservations"
+                ],
+                "summary": "Start a ticket transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ticket ID",
+                        "name": "ticketId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Transfer details",
+                        "name": "transfer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requests.CreateTransferRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/responses.TransferResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ValidationErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid ticket token",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Reservation is not active / Ticket is listed for resale",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{eventId}/tickets/{ticketId}/reservation/transfer/accept": {
+            "post": {
+                "description": "Accept a pending transfer with its one-time code. The reservation moves to the recipient and a new ticket token is issued, invalidating the previous one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reservations"
+                ],
+                "summary": "Accept a ticket transfer",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Ticket ID",
+                        "name": "ticketId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Transfer code",
+                        "name": "transfer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requests.AcceptTransferRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Reservation"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ValidationErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid or expired transfer code",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}": {
+            "get": {
+                "description": "Get details of an event by its ID. Draft events are not found.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Get event by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Event"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete an event by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Delete an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Deleted"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Update the details of an existing event by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Update an existing event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated event details",
+                        "name": "event",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requests.UpdateEventRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Event"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/cancel": {
+            "post": {
+                "description": "Cancel an event that has not been completed yet. Cancelled events cannot be booked.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Cancel an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Event"
+                        }
+                    },
+                    "400": {
+                        "description": "Event date has already passed",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Invalid event status transition",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/clone": {
+            "post": {
+                "description": "Create a new draft event on a different date from an existing one. The clone keeps the source event's timezone unless a new one is given. All tickets are copied as available with the same seat numbers and prices; reservations are not copied.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Clone an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New date and optional overrides",
+                        "name": "event",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requests.CloneEventRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Event"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/export": {
+            "get": {
+                "description": "Download an event together with its tickets and reservations as a versioned JSON archive that can be imported into another environment.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Export an event archive",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.EventArchive"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/manifest": {
+            "get": {
+                "description": "Download the list of attendees of an event with their seat number, ticket category and type, number of places admitted, name, reservation date and status. The format is chosen with the Accept header: CSV (text/csv, the default) or XLSX. Reservation dates are in the event's local timezone. Cancelled reservations are not included.",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Export the attendee manifest of an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "seat",
+                            "name"
+                        ],
+                        "type": "string",
+                        "description": "Sort order (default seat)",
+                        "name": "sort",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Event not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "406": {
+                        "description": "Unsupported format",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/on-sale": {
+            "post": {
+                "description": "Put a published event on sale so that tickets can be reserved. The event moves to SOLD_OUT and back automatically as tickets run out or become available again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Start ticket sales",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Event"
+                        }
+                    },
+                    "400": {
+                        "description": "Event date has already passed",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Invalid event status transition",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/publish": {
+            "post": {
+                "description": "Announce a draft event. Published events are visible in the event list but cannot be booked yet.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Publish an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Event"
+                        }
+                    },
+                    "400": {
+                        "description": "Event date has already passed",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Invalid event status transition",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{id}/stats": {
+            "get": {
+                "description": "Get ticket counts by status, gross revenue and average price of sold tickets, sell-through percentage, cancellations, and reservations per day in the event's local timezone. Monetary values are in minor units.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get sales statistics for an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.EventStats"
+                        }
+                    },
+                    "404": {
+                        "description": "Event not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/healthz": {
+            "get": {
+                "description": "Report that the process is running. This endpoint does not touch the database.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responses.HealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Report whether the service can handle traffic. The database must be reachable and support transactions, which requires a replica set or a sharded cluster. Readiness fails as soon as the server receives SIGTERM.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/responses.HealthResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Database is unavailable or does not support transactions / Server is shutting down",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/reports/summary": {
+            "get": {
+                "description": "Get ticket sales, gross revenue and cancellations for every non-draft event taking place between the given UTC days (inclusive), with totals. Monetary values are in minor units.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get a sales summary across events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "First day of the range (YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day of the range (YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SalesSummary"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/series": {
+            "post": {
+                "description": "Create a recurring event series. One draft event is created for every occurrence of the recurrence rule, each with its own copy of the ticket inventory and the series' category, tags and seating rules. Supported RRULE parts are FREQ (DAILY, WEEKLY, MONTHLY), INTERVAL, COUNT, UNTIL and BYDAY; either COUNT or UNTIL is required and at most 366 events can be created. Occurrences keep the same local time in the series' timezone across DST changes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Series"
+                ],
+                "summary": "Create an event series",
+                "parameters": [
+                    {
+                        "description": "Series details",
+                        "name": "series",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requests.CreateSeriesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.EventSeries"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ValidationErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Seat number is already taken",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/series/{id}": {
+            "get": {
+                "description": "Get details of an event series by its ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Series"
+                ],
+                "summary": "Get event series by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.EventSeries"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Update the details of an event series. Changes are applied to the series and to its future event instances only; past, cancelled and completed instances are left untouched. At least one field must be set.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Series"
+                ],
+                "summary": "Update an event series",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated series details",
+                        "name": "series",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requests.UpdateSeriesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.EventSeries"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ValidationErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/series/{id}/events": {
+            "get": {
+                "description": "Retrieve all event instances that belong to an event series",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Series"
+                ],
+                "summary": "Get events of a series",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Series ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Event"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.Address": {
+            "type": "object",
+            "properties": {
+                "street": {
+                    "type": "string",
+                    "x-order": "0",
+                    "example": "Davutpaşa Cd. No:127"
+                },
+                "city": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "İstanbul"
+                },
+                "postal_code": {
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "34220"
+                },
+                "country": {
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "TR"
+                }
+            }
+        },
+        "models.BestAvailableReservation": {
+            "type": "object",
+            "properties": {
+                "adjacent": {
+                    "type": "boolean",
+                    "x-order": "0",
+                    "example": true
+                },
+                "total_price": {
+                    "type": "integer",
+                    "x-order": "1",
+                    "example": 9998
+                },
+                "tickets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Ticket"
+                    },
+                    "x-order": "2"
+                },
+                "reservations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Reservation"
+                    },
+                    "x-order": "3"
+                }
+            }
+        },
+        "models.CheckInStats": {
+            "type": "object",
+            "properties": {
+                "event_id": {
+                    "type": "string",
+                    "x-order": "0",
+                    "example": "68f0c6a8f5673dc0ec646731"
+                },
+                "reserved": {
+                    "type": "integer",
+                    "x-order": "1",
+                    "example": 1250
+                },
+                "checked_in": {
+                    "type": "integer",
+                    "x-order": "2",
+                    "example": 842
+                }
+            }
+        },
+        "models.DailyReservations": {
+            "type": "object",
+            "properties": {
+                "day": {
+                    "type": "string",
+                    "x-order": "0",
+                    "example": "2025-11-02"
+                },
+                "reservations": {
+                    "type": "integer",
+                    "x-order": "1",
+                    "example": 412
+                },
+                "cancellations": {
+                    "type": "integer",
+                    "x-order": "2",
+                    "example": 9
+                }
+            }
+        },
+        "models.Event": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "x-order": "0",
+                    "example": "68f0c6a8f5673dc0ec646731"
+                },
+                "name": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "FORMULA 1 ETIHAD AIRWAYS ABU DHABI GRAND PRIX 2025"
+                },
+                "date": {
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "2025-12-07T13:00:00Z"
+                },
+                "local_date": {
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "2025-12-07T17:00:00+04:00"
+                },
+                "timezone": {
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "Asia/Dubai"
+                },
+                "venue": {
+                    "type": "string",
+                    "x-order": "5",
+                    "example": "YTÜ Davutpaşa Tarihi Hamam"
+                },
+                "resale_price_cap": {
+                    "type": "integer",
+                    "x-order": "6",
+                    "example": 110
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.EventStatus"
+                        }
+                    ],
+                    "x-order": "7",
+                    "example": "ON_SALE"
+                },
+                "series_id": {
+                    "type": "string",
+                    "x-order": "8",
+                    "example": "68f9b2d3e4f5a6b7c8d9e0f1"
+                },
+                "category": {
+                    "type": "string",
+                    "x-order": "9",
+                    "example": "Motorsport"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "x-order": "10",
+                    "example": [
+                        "formula 1",
+                        "racing"
+                    ]
+                },
+                "address": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Address"
+                        }
+                    ],
+                    "x-order": "11"
+                },
+                "location": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.GeoPoint"
+                        }
+                    ],
+                    "x-order": "12"
+                },
+                "distance_km": {
+                    "type": "number",
+                    "x-order": "13",
+                    "example": 3.42
+                },
+                "seating_rules": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.SeatingRules"
+                        }
+                    ],
+                    "x-order": "14"
+                }
+            }
+        },
+        "models.EventArchive": {
+            "type": "object",
+            "properties": {
+                "version": {
+                    "type": "integer",
+                    "x-order": "0",
+                    "example": 1
+                },
+                "exported_at": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "2025-11-30T09:00:00Z"
+                },
+                "event": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Event"
+                        }
+                    ],
+                    "x-order": "2"
+                },
+                "tickets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Ticket"
+                    },
+                    "x-order": "3"
+                },
+                "reservations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Reservation"
+                    },
+                    "x-order": "4"
+                }
+            }
+        },
+        "models.EventQueue": {
+            "type": "object",
+            "properties": {
+                "event_id": {
+                    "type": "string",
+                    "x-order": "0",
+                    "example": "68f0c6a8f5673dc0ec646731"
+                },
+                "enabled": {
+                    "type": "boolean",
+                    "x-order": "1",
+                    "example": true
+                },
+                "batch_size": {
+                    "type": "integer",
+                    "x-order": "2",
+                    "example": 100
+                },
+                "interval_seconds": {
+                    "type": "integer",
+                    "x-order": "3",
+                    "example": 30
+                },
+                "last_position": {
+                    "type": "integer",
+                    "x-order": "4",
+                    "example": 4210
+                },
+                "admitted_up_to": {
+                    "type": "integer",
+                    "x-order": "5",
+                    "example": 1200
+                },
+                "next_admission_at": {
+                    "type": "string",
+                    "x-order": "6",
+                    "example": "2025-11-20T10:30:30Z"
+                },
+                "waiting": {
+                    "type": "integer",
+                    "x-order": "7",
+                    "example": 3010
+                }
+            }
+        },
+        "models.EventSalesSummary": {
+            "type": "object",
+            "properties": {
+                "event_id": {
+                    "type": "string",
+                    "x-order": "0",
+                    "example": "68f0c6a8f5673dc0ec646731"
+                },
+                "name": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "FORMULA 1 ETIHAD AIRWAYS ABU DHABI GRAND PRIX 2025"
+                },
+                "date": {
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "2025-12-07T13:00:00Z"
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.EventStatus"
+                        }
+                    ],
+                    "x-order": "3",
+                    "example": "SOLD_OUT"
+                },
+                "total_tickets": {
+                    "type": "integer",
+                    "x-order": "4",
+                    "example": 1500
+                },
+                "tickets_sold": {
+                    "type": "integer",
+                    "x-order": "5",
+                    "example": 1500
+                },
+                "gross_revenue": {
+                    "type": "integer",
+                    "x-order": "6",
+                    "example": 7498500
+                },
+                "cancellations": {
+                    "type": "integer",
+                    "x-order": "7",
+                    "example": 21
+                }
+            }
+        },
+        "models.EventSearchFacets": {
+            "type": "object",
+            "properties": {
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FacetCount"
+                    },
+                    "x-order": "0"
+                },
+                "venues": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FacetCount"
+                    },
+                    "x-order": "1"
+                },
+                "months": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FacetCount"
+                    },
+                    "x-order": "2"
+                }
+            }
+        },
+        "models.EventSearchResult": {
+            "type": "object",
+            "properties": {
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ScoredEvent"
+                    },
+                    "x-order": "0"
+                },
+                "facets": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.EventSearchFacets"
+                        }
+                    ],
+                    "x-order": "1"
+                }
+            }
+        },
+        "models.EventSeries": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "x-order": "0",
+                    "example": "68f9b2d3e4f5a6b7c8d9e0f1"
+                },
+                "name": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "The Phantom of the Opera"
+                },
+                "venue": {
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "Zorlu PSM"
+                },
+                "start": {
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "2025-12-01T17:00:00Z"
+                },
+                "timezone": {
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "Europe/Istanbul"
+                },
+                "recurrence": {
+                    "type": "string",
+                    "x-order": "5",
+                    "example": "FREQ=DAILY;COUNT=30"
+                },
+                "resale_price_cap": {
+                    "type": "integer",
+                    "x-order": "6",
+                    "example": 110
+                },
+                "tickets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SeriesTicket"
+                    },
+                    "x-order": "7"
+                },
+                "address": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Address"
+                        }
+                    ],
+                    "x-order": "8"
+                },
+                "location": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.GeoPoint"
+                        }
+                    ],
+                    "x-order": "9"
+                },
+                "category": {
+                    "type": "string",
+                    "x-order": "10",
+                    "example": "Musical"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "x-order": "11",
+                    "example": [
+                        "theatre",
+                        "broadway"
+                    ]
+                },
+                "seating_rules": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.SeatingRules"
+                        }
+                    ],
+                    "x-order": "12"
+                }
+            }
+        },
+        "models.EventStats": {
+            "type": "object",
+            "properties": {
+                "event_id": {
+                    "type": "string",
+                    "x-order": "0",
+                    "example": "68f0c6a8f5673dc0ec646731"
+                },
+                "total_tickets": {
+                    "type": "integer",
+                    "x-order": "1",
+                    "example": 1500
+                },
+                "tickets_by_status": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    },
+                    "x-order": "2"
+                },
+                "gross_revenue": {
+                    "type": "integer",
+                    "x-order": "3",
+                    "example": 6248750
+                },
+                "average_price": {
+                    "type": "integer",
+                    "x-order": "4",
+                    "example": 4999
+                },
+                "sell_through": {
+                    "type": "number",
+                    "x-order": "5",
+                    "example": 83.33
+                },
+                "cancellations": {
+                    "type": "integer",
+                    "x-order": "6",
+                    "example": 37
+                },
+                "reservations_per_day": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DailyReservations"
+                    },
+                    "x-order": "7"
+                }
+            }
+        },
+        "models.EventStatus": {
+            "type": "string",
+            "enum": [
+                "DRAFT",
+                "PUBLISHED",
+                "ON_SALE",
+                "SOLD_OUT",
+                "CANCELLED",
+                "COMPLETED"
+            ],
+            "x-enum-varnames": [
+                "EventStatusDraft",
+                "EventStatusPublished",
+                "EventStatusOnSale",
+                "EventStatusSoldOut",
+                "EventStatusCancelled",
+                "EventStatusCompleted"
+            ]
+        },
+        "models.FacetCount": {
+            "type": "object",
+            "properties": {
+                "value": {
+                    "type": "string",
+                    "x-order": "0",
+                    "example": "Motorsport"
+                },
+                "count": {
+                    "type": "integer",
+                    "x-order": "1",
+                    "example": 12
+                }
+            }
+        },
+        "models.GeoPoint": {
+            "type": "object",
+            "properties": {
+                "type": {
+                    "type": "string",
+                    "x-order": "0",
+                    "example": "Point"
+                },
+                "coordinates": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    },
+                    "x-order": "1",
+                    "example": [
+                        28.8897,
+                        41.0247
+                    ]
+                }
+            }
+        },
+        "models.Listing": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "x-order": "0",
+                    "example": "68f8a1c2d4e5f60718293a4b"
+                },
+                "event_id": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "68f0c6a8f5673dc0ec646731"
+                },
+                "ticket_id": {
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "68f2ab0516a352dc8f40c543"
+                },
+                "reservation_id": {
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "68f4fea9990e605d6589b5f3"
+                },
+                "seller_name": {
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "Lewis Hamilton"
+                },
+                "price": {
+                    "type": "integer",
+                    "x-order": "5",
+                    "example": 5499
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ListingStatus"
+                        }
+                    ],
+                    "x-order": "6",
+                    "example": "ACTIVE"
+                },
+                "created_at": {
+                    "type": "string",
+                    "x-order": "7",
+                    "example": "2025-11-20T10:30:00Z"
+                },
+                "buyer_name": {
+                    "type": "string",
+                    "x-order": "8",
+                    "example": "Max Verstappen"
+                },
+                "sold_at": {
+                    "type": "string",
+                    "x-order": "9",
+                    "example": "2025-11-21T08:45:00Z"
+                }
+            }
+        },
+        "models.ListingStatus": {
+            "type": "string",
+            "enum": [
+                "ACTIVE",
+                "SOLD",
+                "CANCELLED"
+            ],
+            "x-enum-varnames": [
+                "ListingStatusActive",
+                "ListingStatusSold",
+                "ListingStatusCancelled"
+            ]
+        },
+        "models.Payout": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "x-order": "0",
+                    "example": "68f8a3e7d4e5f60718293a4c"
+                },
+                "event_id": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "68f0c6a8f5673dc0ec646731"
+                },
+                "listing_id": {
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "68f8a1c2d4e5f60718293a4b"
+                },
+                "seller_name": {
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "Lewis Hamilton"
+                },
+                "amount": {
+                    "type": "integer",
+                    "x-order": "4",
+                    "example": 5499
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.PayoutStatus"
+                        }
+                    ],
+                    "x-order": "5",
+                    "example": "PENDING"
+                },
+                "created_at": {
+                    "type": "string",
+                    "x-order": "6",
+                    "example": "2025-11-21T08:45:00Z"
+                }
+            }
+        },
+        "models.PayoutStatus": {
+            "type": "string",
+            "enum": [
+                "PENDING",
+                "PAID"
+            ],
+            "x-enum-varnames": [
+                "PayoutStatusPending",
+                "PayoutStatusPaid"
+            ]
+        },
+        "models.PendingTransfer": {
+            "type": "object",
+            "properties": {
+                "recipient_name": {
+                    "type": "string",
+                    "x-order": "0",
+                    "example": "Max Verstappen"
+                },
+                "initiated_at": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "2025-11-20T10:30:00Z"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "2025-11-22T10:30:00Z"
+                }
+            }
+        },
+        "models.QueueEntryStatus": {
+            "type": "string",
+            "enum": [
+                "WAITING",
+                "ADMITTED"
+            ],
+            "x-enum-varnames": [
+                "QueueEntryStatusWaiting",
+                "QueueEntryStatusAdmitted"
+            ]
+        },
+        "models.QueuePosition": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string",
+                    "x-order": "0",
+                    "example": "aPDGqPVnPcDsZGRGaPDGqPVnPcDsZGRG.kq2mX6pVn5cYxN0dEzLw1r6y3gkVtqZJmF2eG8hQpUc"
+                },
+                "event_id": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "68f0c6a8f5673dc0ec646731"
+                },
+                "position": {
+                    "type": "integer",
+                    "x-order": "2",
+                    "example": 1342
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.QueueEntryStatus"
+                        }
+                    ],
+                    "x-order": "3",
+                    "example": "WAITING"
+                },
+                "ahead": {
+                    "type": "integer",
+                    "x-order": "4",
+                    "example": 141
+                },
+                "estimated_wait_seconds": {
+                    "type": "integer",
+                    "x-order": "5",
+                    "example": 60
+                },
+                "expires_at": {
+                    "type": "string",
+                    "x-order": "6",
+                    "example": "2025-11-20T10:40:30Z"
+                }
+            }
+        },
+        "models.Reservation": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "x-order": "0",
+                    "example": "68f4fea9990e605d6589b5f3"
+                },
+                "event_id": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "68f0c6a8f5673dc0ec646731"
+                },
+                "ticket_id": {
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "68f2ab0516a352dc8f40c543"
+                },
+                "customer_name": {
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "Lewis Hamilton"
+                },
+                "reservation_date": {
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "2025-10-19T15:00:00Z"
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ReservationStatus"
+                        }
+                    ],
+                    "x-order": "5",
+                    "example": "ACTIVE"
+                },
+                "token": {
+                    "type": "string",
+                    "x-order": "6",
+                    "example": "9f86d081884c7d659a2feaa0c55ad015"
+                },
+                "checked_in_at": {
+                    "type": "string",
+                    "x-order": "7",
+                    "example": "2025-12-07T18:12:43Z"
+                },
+                "pending_transfer": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.PendingTransfer"
+                        }
+                    ],
+                    "x-order": "8"
+                },
+                "transfers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TransferRecord"
+                    },
+                    "x-order": "9"
+                },
+                "cancelled_at": {
+                    "type": "string",
+                    "x-order": "10",
+                    "example": "2025-11-02T09:14:00Z"
+                },
+                "ticket_type": {
+                    "description": "TicketType is copied from the ticket. Only seated tickets are limited to\none current reservation.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.TicketType"
+                        }
+                    ],
+                    "x-order": "11",
+                    "example": "SEATED"
+                },
+                "quantity": {
+                    "description": "Quantity is the number of general admission places held. It is unset\nfor seated reservations.",
+                    "type": "integer",
+                    "x-order": "12",
+                    "example": 4
+                }
+            }
+        },
+        "models.ReservationStatus": {
+            "type": "string",
+            "enum": [
+                "ACTIVE",
+                "CHECKED_IN",
+                "CANCELLED",
+                "EXPIRED"
+            ],
+            "x-enum-varnames": [
+                "ReservationStatusActive",
+                "ReservationStatusCheckedIn",
+                "ReservationStatusCancelled",
+                "ReservationStatusExpired"
+            ]
+        },
+        "models.SalesSummary": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string",
+                    "x-order": "0",
+                    "example": "2025-12-01"
+                },
+                "to": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "2025-12-31"
+                },
+                "events": {
+                    "type": "integer",
+                    "x-order": "2",
+                    "example": 12
+                },
+                "total_tickets": {
+                    "type": "integer",
+                    "x-order": "3",
+                    "example": 18000
+                },
+                "tickets_sold": {
+                    "type": "integer",
+                    "x-order": "4",
+                    "example": 15420
                 },
-                "reservation_date": {
+                "gross_revenue": {
+                    "type": "integer",
+                    "x-order": "5",
+                    "example": 77084580
+                },
+                "sell_through": {
+                    "type": "number",
+                    "x-order": "6",
+                    "example": 85.67
+                },
+                "cancellations": {
+                    "type": "integer",
+                    "x-order": "7",
+                    "example": 311
+                },
+                "breakdown": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.EventSalesSummary"
+                    },
+                    "x-order": "8"
+                }
+            }
+        },
+        "models.ScoredEvent": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "x-order": "0",
+                    "example": "68f0c6a8f5673dc0ec646731"
+                },
+                "name": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "FORMULA 1 ETIHAD AIRWAYS ABU DHABI GRAND PRIX 2025"
+                },
+                "date": {
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "2025-12-07T13:00:00Z"
+                },
+                "local_date": {
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "2025-12-07T17:00:00+04:00"
+                },
+                "timezone": {
                     "type": "string",
                     "x-order": "4",
-                    "example": "2025-10-1T15:00:00Z"
+                    "example": "Asia/Dubai"
+                },
+                "venue": {
+                    "type": "string",
+                    "x-order": "5",
+                    "example": "YTÜ Davutpaşa Tarihi Hamam"
+                },
+                "resale_price_cap": {
+                    "type": "integer",
+                    "x-order": "6",
+                    "example": 110
                 },
                 "status": {
                     "allOf": [
                         {
-                            "$ref": "#/definitions/models.ReservationStatus"
+                            "$ref": "#/definitions/models.EventStatus"
+                        }
+                    ],
+                    "x-order": "7",
+                    "example": "ON_SALE"
+                },
+                "series_id": {
+                    "type": "string",
+                    "x-order": "8",
+                    "example": "68f9b2d3e4f5a6b7c8d9e0f1"
+                },
+                "category": {
+                    "type": "string",
+                    "x-order": "9",
+                    "example": "Motorsport"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "x-order": "10",
+                    "example": [
+                        "formula 1",
+                        "racing"
+                    ]
+                },
+                "address": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Address"
+                        }
+                    ],
+                    "x-order": "11"
+                },
+                "location": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.GeoPoint"
+                        }
+                    ],
+                    "x-order": "12"
+                },
+                "distance_km": {
+                    "type": "number",
+                    "x-order": "13",
+                    "example": 3.42
+                },
+                "seating_rules": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.SeatingRules"
                         }
                     ],
+                    "x-order": "14"
+                },
+                "score": {
+                    "type": "number",
+                    "example": 1.75
+                }
+            }
+        },
+        "models.SeatingRules": {
+            "type": "object",
+            "properties": {
+                "no_single_gaps": {
+                    "description": "NoSingleGaps rejects reservations that would leave a single empty seat\nbetween two occupied seats in a row.",
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.SeriesTicket": {
+            "type": "object",
+            "properties": {
+                "seat_number": {
+                    "type": "string",
+                    "x-order": "0",
+                    "example": "A12"
+                },
+                "price": {
+                    "type": "integer",
+                    "x-order": "1",
+                    "example": 4999
+                },
+                "category": {
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "Stalls"
+                },
+                "section": {
+                    "type": "string",
+                    "x-order": "3",
+                    "example": "North Stand"
+                },
+                "row": {
+                    "type": "string",
+                    "x-order": "4",
+                    "example": "A"
+                },
+                "seat": {
+                    "type": "integer",
                     "x-order": "5",
-                    "example": "ACTIVE"
+                    "example": 12
+                },
+                "capacity": {
+                    "type": "integer",
+                    "x-order": "6",
+                    "example": 500
                 }
             }
         },
-        "models.ReservationStatus": {
+        "models.Ticket": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string",
+                    "x-order": "0",
+                    "example": "68f2ab0516a352dc8f40c543"
+                },
+                "event_id": {
+                    "type": "string",
+                    "x-order": "1",
+                    "example": "68f0c6a8f5673dc0ec646731"
+                },
+                "seat_number": {
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "A12"
+                },
+                "price": {
+                    "type": "integer",
+                    "x-order": "3",
+                    "example": 4999
+                },
+                "status": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.TicketStatus"
+                        }
+                    ],
+                    "x-order": "4",
+                    "example": "AVAILABLE"
+                },
+                "category": {
+                    "type": "string",
+                    "x-order": "5",
+                    "example": "Grandstand"
+                },
+                "section": {
+                    "type": "string",
+                    "x-order": "6",
+                    "example": "North Stand"
+                },
+                "row": {
+                    "type": "string",
+                    "x-order": "7",
+                    "example": "A"
+                },
+                "seat": {
+                    "type": "integer",
+                    "x-order": "8",
+                    "example": 12
+                },
+                "type": {
+                    "description": "Type is GENERAL_ADMISSION for unnumbered tickets sold up to Capacity.\nTickets without a type are seated.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.TicketType"
+                        }
+                    ],
+                    "x-order": "9",
+                    "example": "GENERAL_ADMISSION"
+                },
+                "capacity": {
+                    "type": "integer",
+                    "x-order": "10",
+                    "example": 500
+                },
+                "remaining": {
+                    "type": "integer",
+                    "x-order": "11",
+                    "example": 212
+                }
+            }
+        },
+        "models.TicketStatus": {
             "type": "string",
             "enum": [
-                "ACTIVE",
-                "CANCELLED"
+                "AVAILABLE",
+                "RESERVED"
             ],
             "x-enum-varnames": [
-                "ReservationStatusActive",
-                "ReservationStatusCancelled"
+                "TicketStatusAvailable",
+                "TicketStatusReserved"
             ]
         },
-        "models.Ticket": {
+        "models.TicketType": {
+            "type": "string",
+            "enum": [
+                "SEATED",
+                "GENERAL_ADMISSION"
+            ],
+            "x-enum-varnames": [
+                "TicketTypeSeated",
+                "TicketTypeGeneralAdmission"
+            ]
+        },
+        "models.TransferMethod": {
+            "type": "string",
+            "enum": [
+                "TRANSFER",
+                "RESALE"
+            ],
+            "x-enum-varnames": [
+                "TransferMethodTransfer",
+                "TransferMethodResale"
+            ]
+        },
+        "models.TransferRecord": {
             "type": "object",
             "properties": {
-                "id": {
+                "from_customer_name": {
                     "type": "string",
                     "x-order": "0",
-                    "example": "68f2ab0516a352dc8f40c543"
+                    "example": "Lewis Hamilton"
                 },
-                "event_id": {
+                "to_customer_name": {
                     "type": "string",
                     "x-order": "1",
-                    "example": "68f0c6a8f5673dc0ec646731"
+                    "example": "Max Verstappen"
+                },
+                "transferred_at": {
+                    "type": "string",
+                    "x-order": "2",
+                    "example": "2025-11-21T08:45:00Z"
+                },
+                "method": {
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.TransferMethod"
+                        }
+                    ],
+                    "x-order": "3",
+                    "example": "TRANSFER"
+                }
+            }
+        },
+        "requests.AcceptTransferRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "3c59dc048e8850243be8079a5c74d079"
+                }
+            }
+        },
+        "requests.AddressRequest": {
+            "type": "object",
+            "required": [
+                "city",
+                "country"
+            ],
+            "properties": {
+                "city": {
+                    "type": "string",
+                    "example": "İstanbul"
+                },
+                "country": {
+                    "type": "string",
+                    "example": "TR"
+                },
+                "postal_code": {
+                    "type": "string",
+                    "example": "34220"
+                },
+                "street": {
+                    "type": "string",
+                    "example": "Davutpaşa Cd. No:127"
+                }
+            }
+        },
+        "requests.BestAvailableRequest": {
+            "type": "object",
+            "required": [
+                "customer_name",
+                "quantity"
+            ],
+            "properties": {
+                "customer_name": {
+                    "type": "string",
+                    "example": "Enes Genç"
+                },
+                "max_price": {
+                    "type": "integer",
+                    "example": 7500
+                },
+                "quantity": {
+                    "type": "integer",
+                    "maximum": 10,
+                    "minimum": 1,
+                    "example": 2
+                },
+                "section": {
+                    "type": "string",
+                    "example": "Grandstand"
+                }
+            }
+        },
+        "requests.CancelListingRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string",
+                    "example": "9f86d081884c7d659a2feaa0c55ad015"
+                }
+            }
+        },
+        "requests.CloneEventRequest": {
+            "type": "object",
+            "required": [
+                "date"
+            ],
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2026-12-06T17:00:00"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "FORMULA 1 ETIHAD AIRWAYS ABU DHABI GRAND PRIX 2026"
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Asia/Dubai"
+                },
+                "venue": {
+                    "type": "string",
+                    "example": "YTÜ Davutpaşa Tarihi Hamam"
+                }
+            }
+        },
+        "requests.ConfigureQueueRequest": {
+            "type": "object",
+            "required": [
+                "batch_size",
+                "interval_seconds"
+            ],
+            "properties": {
+                "batch_size": {
+                    "type": "integer",
+                    "maximum": 10000,
+                    "minimum": 1,
+                    "example": 100
                 },
-                "seat_number": {
-                    "type": "string",
-                    "x-order": "2",
-                    "example": "A12"
+                "enabled": {
+                    "type": "boolean",
+                    "example": true
                 },
-                "price": {
+                "interval_seconds": {
                     "type": "integer",
-                    "x-order": "3",
-                    "example": 4999
-                },
-                "status": {
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/models.TicketStatus"
-                        }
-                    ],
-                    "x-order": "4",
-                    "example": "AVAILABLE"
+                    "maximum": 3600,
+                    "minimum": 1,
+                    "example": 30
                 }
             }
         },
-        "models.TicketStatus": {
-            "type": "string",
-            "enum": [
-                "AVAILABLE",
-                "RESERVED"
+        "requests.CreateCheckInRequest": {
+            "type": "object",
+            "required": [
+                "token"
             ],
-            "x-enum-varnames": [
-                "TicketStatusAvailable",
-                "TicketStatusReserved"
-            ]
+            "properties": {
+                "token": {
+                    "type": "string",
+                    "example": "9f86d081884c7d659a2feaa0c55ad015"
+                }
+            }
         },
         "requests.CreateEventRequest": {
             "type": "object",
             "required": [
                 "date",
                 "name",
+                "tags",
                 "venue"
             ],
             "properties": {
+                "address": {
+                    "$ref": "#/definitions/requests.AddressRequest"
+                },
+                "category": {
+                    "type": "string",
+                    "example": "Motorsport"
+                },
                 "date": {
                     "type": "string",
-                    "example": "2025-12-07T16:00:00+03:00"
+                    "example": "2025-12-07T17:00:00"
+                },
+                "location": {
+                    "$ref": "#/definitions/requests.LocationRequest"
                 },
                 "name": {
                     "type": "string",
                     "example": "FORMULA 1 ETIHAD AIRWAYS ABU DHABI GRAND PRIX 2025"
                 },
+                "resale_price_cap": {
+                    "type": "integer",
+                    "example": 110
+                },
+                "seating_rules": {
+                    "$ref": "#/definitions/requests.SeatingRulesRequest"
+                },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "formula 1",
+                        "racing"
+                    ]
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Asia/Dubai"
+                },
                 "venue": {
                     "type": "string",
                     "example": "YTÜ Davutpaşa Tarihi Hamam"
                 }
             }
         },
+        "requests.CreateListingRequest": {
+            "type": "object",
+            "required": [
+                "price",
+                "ticket_id",
+                "token"
+            ],
+            "properties": {
+                "price": {
+                    "type": "integer",
+                    "example": 5499
+                },
+                "ticket_id": {
+                    "type": "string",
+                    "example": "68f2ab0516a352dc8f40c543"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "9f86d081884c7d659a2feaa0c55ad015"
+                }
+            }
+        },
         "requests.CreateReservationRequest": {
             "type": "object",
             "required": [
@@ -900,62 +4082,296 @@ const docTemplate = `{
                 "customer_name": {
                     "type": "string",
                     "example": "Enes Genç"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "maximum": 10,
+                    "minimum": 1,
+                    "example": 1
+                }
+            }
+        },
+        "requests.CreateSeriesRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "recurrence",
+                "start",
+                "tags",
+                "venue"
+            ],
+            "properties": {
+                "address": {
+                    "$ref": "#/definitions/requests.AddressRequest"
+                },
+                "category": {
+                    "type": "string",
+                    "example": "Musical"
+                },
+                "location": {
+                    "$ref": "#/definitions/requests.LocationRequest"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "The Phantom of the Opera"
+                },
+                "recurrence": {
+                    "type": "string",
+                    "example": "FREQ=DAILY;COUNT=30"
+                },
+                "resale_price_cap": {
+                    "type": "integer",
+                    "example": 110
+                },
+                "seating_rules": {
+                    "$ref": "#/definitions/requests.SeatingRulesRequest"
+                },
+                "start": {
+                    "type": "string",
+                    "example": "2025-12-01T20:00:00"
+                },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "theatre",
+                        "broadway"
+                    ]
+                },
+                "tickets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/requests.CreateTicketRequest"
+                    }
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Europe/Istanbul"
+                },
+                "venue": {
+                    "type": "string",
+                    "example": "Zorlu PSM"
                 }
             }
         },
         "requests.CreateTicketRequest": {
             "type": "object",
             "required": [
-                "price",
-                "seat_number"
+                "price"
             ],
             "properties": {
+                "capacity": {
+                    "type": "integer",
+                    "maximum": 1000000,
+                    "example": 500
+                },
+                "category": {
+                    "type": "string",
+                    "example": "Grandstand"
+                },
                 "price": {
                     "type": "integer",
                     "example": 4999
                 },
+                "row": {
+                    "type": "string",
+                    "example": "A"
+                },
+                "seat": {
+                    "type": "integer",
+                    "example": 12
+                },
                 "seat_number": {
                     "type": "string",
                     "example": "A12"
+                },
+                "section": {
+                    "type": "string",
+                    "example": "North Stand"
+                }
+            }
+        },
+        "requests.CreateTransferRequest": {
+            "type": "object",
+            "required": [
+                "recipient_name",
+                "token"
+            ],
+            "properties": {
+                "recipient_name": {
+                    "type": "string",
+                    "example": "Max Verstappen"
+                },
+                "token": {
+                    "type": "string",
+                    "example": "9f86d081884c7d659a2feaa0c55ad015"
+                }
+            }
+        },
+        "requests.LocationRequest": {
+            "type": "object",
+            "required": [
+                "latitude",
+                "longitude"
+            ],
+            "properties": {
+                "latitude": {
+                    "type": "number",
+                    "example": 41.0247
+                },
+                "longitude": {
+                    "type": "number",
+                    "example": 28.8897
+                }
+            }
+        },
+        "requests.PurchaseListingRequest": {
+            "type": "object",
+            "required": [
+                "customer_name"
+            ],
+            "properties": {
+                "customer_name": {
+                    "type": "string",
+                    "example": "Max Verstappen"
+                }
+            }
+        },
+        "requests.SeatingRulesRequest": {
+            "type": "object",
+            "properties": {
+                "no_single_gaps": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
         "requests.UpdateEventRequest": {
             "type": "object",
+            "required": [
+                "tags"
+            ],
             "properties": {
+                "address": {
+                    "$ref": "#/definitions/requests.AddressRequest"
+                },
+                "category": {
+                    "type": "string",
+                    "example": "Motorsport"
+                },
                 "date": {
                     "type": "string",
-                    "example": "2025-12-07T16:00:00+03:00"
+                    "example": "2025-12-07T17:00:00"
+                },
+                "location": {
+                    "$ref": "#/definitions/requests.LocationRequest"
                 },
                 "name": {
                     "type": "string",
                     "example": "FORMULA 1 ETIHAD AIRWAYS ABU DHABI GRAND PRIX 2025"
                 },
+                "resale_price_cap": {
+                    "type": "integer",
+                    "example": 110
+                },
+                "seating_rules": {
+                    "$ref": "#/definitions/requests.SeatingRulesRequest"
+                },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "formula 1",
+                        "racing"
+                    ]
+                },
+                "timezone": {
+                    "type": "string",
+                    "example": "Asia/Dubai"
+                },
                 "venue": {
                     "type": "string",
                     "example": "YTÜ Davutpaşa Tarihi Hamam"
                 }
             }
         },
-        "requests.UpdateReservationRequest": {
+        "requests.UpdateSeriesRequest": {
             "type": "object",
             "properties": {
-                "customer_name": {
+                "address": {
+                    "$ref": "#/definitions/requests.AddressRequest"
+                },
+                "location": {
+                    "$ref": "#/definitions/requests.LocationRequest"
+                },
+                "name": {
                     "type": "string",
-                    "example": "Enes Genç"
+                    "example": "The Phantom of the Opera"
+                },
+                "resale_price_cap": {
+                    "type": "integer",
+                    "example": 110
+                },
+                "venue": {
+                    "type": "string",
+                    "example": "Zorlu PSM"
                 }
             }
         },
         "requests.UpdateTicketRequest": {
             "type": "object",
             "properties": {
+                "capacity": {
+                    "type": "integer",
+                    "maximum": 1000000,
+                    "example": 500
+                },
+                "category": {
+                    "type": "string",
+                    "example": "Grandstand"
+                },
                 "price": {
                     "type": "integer",
                     "example": 4999
                 },
+                "row": {
+                    "type": "string",
+                    "example": "A"
+                },
+                "seat": {
+                    "type": "integer",
+                    "example": 12
+                },
                 "seat_number": {
                     "type": "string",
                     "example": "A12"
+                },
+                "section": {
+                    "type": "string",
+                    "example": "North Stand"
+                }
+            }
+        },
+        "responses.AlreadyCheckedInResponse": {
+            "type": "object",
+            "properties": {
+                "checked_in_at": {
+                    "type": "string",
+                    "example": "2025-12-07T18:12:43Z"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "Ticket has already been checked in"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "4f1c2a7e-9b3d-4e8a-a6f0-2d5c8b1e7a93"
                 }
             }
         },
@@ -965,6 +4381,84 @@ const docTemplate = `{
                 "message": {
                     "type": "string",
                     "example": "Error message"
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "4f1c2a7e-9b3d-4e8a-a6f0-2d5c8b1e7a93"
+                }
+            }
+        },
+        "responses.HealthResponse": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "responses.TicketImportResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer",
+                    "example": 0
+                },
+                "dry_run": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/responses.TicketImportRowError"
+                    }
+                },
+                "rows": {
+                    "type": "integer",
+                    "example": 250
+                },
+                "tickets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Ticket"
+                    }
+                },
+                "valid": {
+                    "type": "integer",
+                    "example": 248
+                }
+            }
+        },
+        "responses.TicketImportRowError": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/validator.ValidationError"
+                    }
+                },
+                "line": {
+                    "type": "integer",
+                    "example": 17
+                }
+            }
+        },
+        "responses.TransferResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "3c59dc048e8850243be8079a5c74d079"
+                },
+                "expires_at": {
+                    "type": "string",
+                    "example": "2025-11-22T10:30:00Z"
+                },
+                "recipient_name": {
+                    "type": "string",
+                    "example": "Max Verstappen"
                 }
             }
         },
@@ -976,6 +4470,10 @@ const docTemplate = `{
                     "items": {
                         "$ref": "#/definitions/validator.ValidationError"
                     }
+                },
+                "request_id": {
+                    "type": "string",
+                    "example": "4f1c2a7e-9b3d-4e8a-a6f0-2d5c8b1e7a93"
                 }
             }
         },
@@ -998,6 +4496,10 @@ const docTemplate = `{
             "description": "APIs related to event management in SkyTicket.",
             "name": "Events"
         },
+        {
+            "description": "APIs related to recurring event series. A series creates one event per occurrence of its recurrence rule.",
+            "name": "Series"
+        },
         {
             "description": "APIs related to ticket management in SkyTicket. SkyTicket expects monetary values to be represented in the smallest currency units (\"kuruş\" for Turkish lira) to avoid floating-point precision issues.",
             "name": "Tickets"
@@ -1005,6 +4507,26 @@ const docTemplate = `{
         {
             "description": "APIs related to ticket reservations in SkyTicket.",
             "name": "Reservations"
+        },
+        {
+            "description": "APIs related to the virtual queue. High-demand events can put buyers in a queue that admits them in batches before they can reserve tickets.",
+            "name": "Queue"
+        },
+        {
+            "description": "APIs related to the resale marketplace. Resale prices are capped per event as a percentage of the ticket's face value.",
+            "name": "Resale"
+        },
+        {
+            "description": "APIs related to admitting attendees at the venue gates. Reservations carry a token that is scanned at the door.",
+            "name": "Check-ins"
+        },
+        {
+            "description": "APIs related to sales and occupancy reporting. Monetary values are in minor units.",
+            "name": "Reports"
+        },
+        {
+            "description": "Liveness and readiness probes for orchestrators.",
+            "name": "Health"
         }
     ]
 }`
@@ -1016,7 +4538,7 @@ var SwaggerInfo = &swag.Spec{
 	BasePath:         "",
 	Schemes:          []string{"https"},
 	Title:            "SkyTicket",
-	Description:      "This is the API documentation for SkyTicket.",
+	Description:      "This is the API documentation for SkyTicket.\nEvery response carries an X-Request-ID header, taken from the request when one is sent. JSON error responses also include it as request_id.",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
 	LeftDelim:        "{{",
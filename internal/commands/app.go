@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"context"
+	"crypto/rand"
+
+	"github.com/enxg/skyticket/internal/config"
+	"github.com/enxg/skyticket/internal/repositories"
+	"github.com/enxg/skyticket/internal/services"
+	"github.com/enxg/skyticket/internal/tracing"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type app struct {
+	config config.Config
+	client *mongo.Client
+	db     *mongo.Database
+
+	eventService       services.EventService
+	ticketService      services.TicketService
+	reservationService services.ReservationService
+	checkInService     services.CheckInService
+	resaleService      services.ResaleService
+	seriesService      services.SeriesService
+	reportService      services.ReportService
+	healthService      services.HealthService
+	queueService       services.QueueService
+}
+
+func newApp(cfg config.Config) (*app, error) {
+	client, err := mongo.Connect(options.Client().
+		ApplyURI(string(cfg.Database.URI)).
+		SetConnectTimeout(cfg.Database.ConnectTimeout).
+		SetMonitor(tracing.NewCommandMonitor()))
+	if err != nil {
+		return nil, err
+	}
+
+	db := client.Database(cfg.Database.Name)
+
+	eventRepository := repositories.NewEventRepository(db)
+	ticketRepository := repositories.NewTicketRepository(db)
+	reservationRepository := repositories.NewReservationRepository(db)
+	listingRepository := repositories.NewListingRepository(db)
+	payoutRepository := repositories.NewPayoutRepository(db)
+	seriesRepository := repositories.NewSeriesRepository(db)
+	queueRepository := repositories.NewQueueRepository(db)
+
+	queueSecret := []byte(cfg.Queue.Secret)
+	if len(queueSecret) == 0 {
+		queueSecret = make([]byte, 32)
+		_, err = rand.Read(queueSecret)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &app{
+		config:             cfg,
+		client:             client,
+		db:                 db,
+		eventService:       services.NewEventService(eventRepository, ticketRepository, reservationRepository, listingRepository, client),
+		ticketService:      services.NewTicketService(ticketRepository, eventRepository, reservationRepository, listingRepository, client),
+		reservationService: services.NewReservationService(reservationRepository, ticketRepository, eventRepository, listingRepository, client, cfg.Reservations.TransferCodeTTL),
+		checkInService:     services.NewCheckInService(reservationRepository, eventRepository),
+		resaleService:      services.NewResaleService(listingRepository, payoutRepository, reservationRepository, ticketRepository, eventRepository, client),
+		seriesService:      services.NewSeriesService(seriesRepository, eventRepository, ticketRepository, client),
+		reportService:      services.NewReportService(eventRepository, ticketRepository, reservationRepository),
+		healthService:      services.NewHealthService(client),
+		queueService:       services.NewQueueService(queueRepository, eventRepository, queueSecret, cfg.Queue.AdmissionTTL),
+	}, nil
+}
+
+func (a *app) close(ctx context.Context) error {
+	return a.client.Disconnect(ctx)
+}
@@ -0,0 +1,128 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/enxg/skyticket/internal/config"
+	"github.com/enxg/skyticket/pkg/validator"
+	"github.com/urfave/cli/v2"
+)
+
+// New builds the skyticket command line. Running the binary without a
+// subcommand starts the HTTP server.
+func New() *cli.App {
+	return &cli.App{
+		Name:  "skyticket",
+		Usage: "Event ticketing API and administration tools",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "config",
+				Aliases: []string{"c"},
+				Usage:   "load configuration from a YAML or TOML `FILE`",
+				EnvVars: []string{"CONFIG_FILE"},
+			},
+			&cli.StringSliceFlag{
+				Name:  "set",
+				Usage: "override any setting of the configuration file, e.g. --set server.read_timeout=10s (repeatable)",
+			},
+			&cli.StringFlag{
+				Name:  "listen",
+				Usage: "listen on `ADDRESS` (overrides server.address)",
+			},
+			&cli.StringFlag{
+				Name:  "mongodb-uri",
+				Usage: "connect to MongoDB at `URI` (overrides database.uri)",
+			},
+			&cli.StringFlag{
+				Name:  "database",
+				Usage: "use the database `NAME` (overrides database.name)",
+			},
+		},
+		Action: serve,
+		Commands: []*cli.Command{
+			serveCommand,
+			migrateCommand,
+			seedCommand,
+			eventsCommand,
+			ticketsCommand,
+			reservationsCommand,
+			configCommand,
+		},
+	}
+}
+
+// loadConfig layers the global flags over the file and environment
+// configuration.
+func loadConfig(c *cli.Context) (config.Config, error) {
+	cfg, err := config.Load(c.String("config"))
+	if err != nil {
+		return config.Config{}, err
+	}
+
+	for _, assignment := range c.StringSlice("set") {
+		err = cfg.Set(assignment)
+		if err != nil {
+			return config.Config{}, fmt.Errorf("--set %w", err)
+		}
+	}
+
+	if c.IsSet("listen") {
+		cfg.Server.Address = c.String("listen")
+	}
+
+	if c.IsSet("mongodb-uri") {
+		cfg.Database.URI = config.Secret(c.String("mongodb-uri"))
+	}
+
+	if c.IsSet("database") {
+		cfg.Database.Name = c.String("database")
+	}
+
+	return cfg, nil
+}
+
+// withApp connects to MongoDB for the duration of a command.
+func withApp(action func(c *cli.Context, a *app) error) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		cfg, err := loadConfig(c)
+		if err != nil {
+			return err
+		}
+
+		err = cfg.Validate()
+		if err != nil {
+			return err
+		}
+
+		a, err := newApp(cfg)
+		if err != nil {
+			return err
+		}
+		defer a.close(c.Context)
+
+		return action(c, a)
+	}
+}
+
+// validate checks command input with the same rules the HTTP API applies to
+// request bodies.
+func validate(structValidator validator.StructValidator, data any) error {
+	err := structValidator.Validate(data)
+	if err == nil {
+		return nil
+	}
+
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return err
+	}
+
+	messages := make([]string, 0, len(validationErrors))
+	for _, ve := range validator.ParseValidationErrors(validationErrors) {
+		messages = append(messages, ve.Error)
+	}
+
+	return fmt.Errorf("invalid input: %s", strings.Join(messages, " "))
+}
@@ -0,0 +1,38 @@
+package commands
+
+import (
+	"github.com/urfave/cli/v2"
+)
+
+var configCommand = &cli.Command{
+	Name:  "config",
+	Usage: "Inspect the configuration",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "print",
+			Usage:  "Print the effective configuration with secrets redacted",
+			Action: printConfig,
+		},
+	},
+}
+
+// printConfig prints the configuration even when it is invalid, so that the
+// output can be used to find the problem.
+func printConfig(c *cli.Context) error {
+	cfg, err := loadConfig(c)
+	if err != nil {
+		return err
+	}
+
+	out, err := cfg.YAML()
+	if err != nil {
+		return err
+	}
+
+	_, err = c.App.Writer.Write(out)
+	if err != nil {
+		return err
+	}
+
+	return cfg.Validate()
+}
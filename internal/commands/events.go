@@ -0,0 +1,226 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/enxg/skyticket/internal/models"
+	"github.com/enxg/skyticket/internal/requests"
+	"github.com/enxg/skyticket/internal/services"
+	"github.com/enxg/skyticket/pkg/validator"
+	"github.com/urfave/cli/v2"
+)
+
+var errMissingArgument = errors.New("missing argument")
+
+var eventsCommand = &cli.Command{
+	Name:  "events",
+	Usage: "Manage events",
+	Subcommands: []*cli.Command{
+		{
+			Name:  "list",
+			Usage: "List events",
+			Flags: []cli.Flag{
+				&cli.StringFlag{Name: "status", Usage: "only list events with this `STATUS`; drafts are hidden otherwise"},
+				&cli.StringFlag{Name: "day", Usage: "only list events on this local `YYYY-MM-DD`"},
+				&cli.StringFlag{Name: "category", Usage: "only list events in this `CATEGORY`"},
+				&cli.StringFlag{Name: "tag", Usage: "only list events with this `TAG`"},
+				&cli.BoolFlag{Name: "json", Usage: "print events as JSON"},
+			},
+			Action: withApp(listEvents),
+		},
+		{
+			Name:  "create",
+			Usage: "Create a draft event",
+			Flags: []cli.Flag{
+				&cli.StringFlag{Name: "name", Required: true},
+				&cli.StringFlag{Name: "date", Required: true, Usage: "RFC3339 timestamp, or local date-time when --timezone is set"},
+				&cli.StringFlag{Name: "timezone", Usage: "IANA time zone of the venue"},
+				&cli.StringFlag{Name: "venue", Required: true},
+				&cli.StringFlag{Name: "category"},
+				&cli.StringSliceFlag{Name: "tag", Usage: "tag the event, can be repeated"},
+				&cli.IntFlag{Name: "resale-price-cap", Usage: "resale price cap as a percentage of face value"},
+			},
+			Action: withApp(createEvent),
+		},
+		{
+			Name:      "delete",
+			Usage:     "Delete an event with its tickets, reservations and listings",
+			ArgsUsage: "<event-id>",
+			Action:    withApp(deleteEvent),
+		},
+		{
+			Name:      "export",
+			Usage:     "Export an event with its tickets and reservations as a JSON archive",
+			ArgsUsage: "<event-id>",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "output",
+					Aliases: []string{"o"},
+					Usage:   "write the archive to `FILE` instead of stdout",
+				},
+			},
+			Action: withApp(exportEvent),
+		},
+		{
+			Name:      "import",
+			Usage:     "Import an event archive, remapping all IDs",
+			ArgsUsage: "<archive.json|->",
+			Action:    withApp(importEvent),
+		},
+	},
+}
+
+func listEvents(c *cli.Context, a *app) error {
+	data := requests.GetEventsRequest{
+		Status:   c.String("status"),
+		Day:      c.String("day"),
+		Category: c.String("category"),
+		Tag:      c.String("tag"),
+	}
+
+	err := validate(validator.NewStructValidator(), data)
+	if err != nil {
+		return err
+	}
+
+	events, err := a.eventService.GetAllEvents(c.Context, models.EventStatus(data.Status), data.Day, data.Category, data.Tag, nil, 0)
+	if err != nil {
+		return err
+	}
+
+	if c.Bool("json") {
+		encoder := json.NewEncoder(c.App.Writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(events)
+	}
+
+	w := tabwriter.NewWriter(c.App.Writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME\tLOCAL DATE\tVENUE\tSTATUS")
+	for _, event := range events {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", event.ID.Hex(), event.Name, event.LocalDate, event.Venue, event.Status)
+	}
+
+	return w.Flush()
+}
+
+func createEvent(c *cli.Context, a *app) error {
+	data := requests.CreateEventRequest{
+		Name:           c.String("name"),
+		Date:           c.String("date"),
+		Timezone:       c.String("timezone"),
+		Venue:          c.String("venue"),
+		ResalePriceCap: c.Int("resale-price-cap"),
+		Category:       c.String("category"),
+		Tags:           c.StringSlice("tag"),
+	}
+
+	err := validate(validator.NewStructValidator(), data)
+	if err != nil {
+		return err
+	}
+
+	date, err := requests.ParseDate(data.Date, data.Timezone)
+	if err != nil {
+		return err
+	}
+
+	if time.Now().After(date) {
+		return errors.New("event date cannot be in the past")
+	}
+
+	event, err := a.eventService.CreateEvent(c.Context, services.EventInput{
+		Name:           data.Name,
+		Date:           date,
+		Timezone:       data.Timezone,
+		Venue:          data.Venue,
+		ResalePriceCap: data.ResalePriceCap,
+		Category:       data.Category,
+		Tags:           data.Tags,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.App.Writer, "Created draft event %s\n", event.ID.Hex())
+	return nil
+}
+
+func deleteEvent(c *cli.Context, a *app) error {
+	id := c.Args().First()
+	if id == "" {
+		return fmt.Errorf("%w: event ID", errMissingArgument)
+	}
+
+	err := a.eventService.DeleteEvent(c.Context, id)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.App.Writer, "Deleted event %s\n", id)
+	return nil
+}
+
+func exportEvent(c *cli.Context, a *app) error {
+	id := c.Args().First()
+	if id == "" {
+		return fmt.Errorf("%w: event ID", errMissingArgument)
+	}
+
+	archive, err := a.eventService.ExportEvent(c.Context, id)
+	if err != nil {
+		return err
+	}
+
+	out := io.Writer(os.Stdout)
+	if path := c.String("output"); path != "" {
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		out = f
+	}
+
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(archive)
+}
+
+func importEvent(c *cli.Context, a *app) error {
+	path := c.Args().First()
+	if path == "" {
+		return fmt.Errorf("%w: archive path", errMissingArgument)
+	}
+
+	in := io.Reader(os.Stdin)
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		in = f
+	}
+
+	var archive models.EventArchive
+	err := json.NewDecoder(in).Decode(&archive)
+	if err != nil {
+		return err
+	}
+
+	event, err := a.eventService.ImportEvent(c.Context, archive)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.App.Writer, "Imported event %s (%s) with %d tickets and %d reservations\n", event.ID.Hex(), event.Name, len(archive.Tickets), len(archive.Reservations))
+	return nil
+}
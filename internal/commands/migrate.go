@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/enxg/skyticket/internal/migrations"
+	"github.com/urfave/cli/v2"
+)
+
+var migrateCommand = &cli.Command{
+	Name:   "migrate",
+	Usage:  "Apply pending database migrations",
+	Action: withApp(migrate),
+	Subcommands: []*cli.Command{
+		{
+			Name:   "status",
+			Usage:  "List migrations and whether they have been applied",
+			Action: withApp(migrateStatus),
+		},
+	},
+}
+
+func migrate(c *cli.Context, a *app) error {
+	applied, err := migrations.Run(c.Context, a.db)
+	for _, m := range applied {
+		fmt.Fprintf(c.App.Writer, "Applied %d: %s\n", m.Version, m.Description)
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(c.App.Writer, "Database is up to date")
+	return nil
+}
+
+func migrateStatus(c *cli.Context, a *app) error {
+	statuses, err := migrations.Statuses(c.Context, a.db)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(c.App.Writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tDESCRIPTION\tAPPLIED AT")
+	for _, s := range statuses {
+		appliedAt := "pending"
+		if s.AppliedAt != nil {
+			appliedAt = s.AppliedAt.Format(time.RFC3339)
+		}
+
+		fmt.Fprintf(w, "%d\t%s\t%s\n", s.Migration.Version, s.Migration.Description, appliedAt)
+	}
+
+	return w.Flush()
+}
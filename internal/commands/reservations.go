@@ -0,0 +1,29 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+)
+
+var reservationsCommand = &cli.Command{
+	Name:  "reservations",
+	Usage: "Manage reservations",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "expire",
+			Usage:  "Mark active reservations of past events as expired",
+			Action: withApp(expireReservations),
+		},
+	},
+}
+
+func expireReservations(c *cli.Context, a *app) error {
+	n, err := a.reservationService.ExpireReservations(c.Context)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.App.Writer, "Expired %d reservations\n", n)
+	return nil
+}
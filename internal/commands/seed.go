@@ -0,0 +1,120 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/enxg/skyticket/internal/models"
+	"github.com/enxg/skyticket/internal/services"
+	"github.com/urfave/cli/v2"
+)
+
+var seedCommand = &cli.Command{
+	Name:  "seed",
+	Usage: "Create demo events with tickets that are on sale",
+	Flags: []cli.Flag{
+		&cli.IntFlag{
+			Name:  "seats",
+			Value: 50,
+			Usage: "number of seats per event",
+		},
+	},
+	Action: withApp(seed),
+}
+
+type demoEvent struct {
+	name     string
+	venue    string
+	category string
+	tags     []string
+	days     int
+	price    int
+}
+
+var demoEvents = []demoEvent{
+	{
+		name:     "SkyLab Tech Summit",
+		venue:    "YTÜ Davutpaşa Kongre ve Kültür Merkezi",
+		category: "Conference",
+		tags:     []string{"technology", "students"},
+		days:     30,
+		price:    25000,
+	},
+	{
+		name:     "Bosphorus Jazz Night",
+		venue:    "Cemil Topuzlu Açıkhava Tiyatrosu",
+		category: "Music",
+		tags:     []string{"jazz", "live"},
+		days:     45,
+		price:    75000,
+	},
+	{
+		name:     "The Phantom of the Opera",
+		venue:    "Zorlu PSM",
+		category: "Theatre",
+		tags:     []string{"musical"},
+		days:     60,
+		price:    120000,
+	},
+}
+
+const (
+	demoTimezone    = "Europe/Istanbul"
+	demoSeatsPerRow = 10
+	demoMaxSeats    = demoSeatsPerRow * 26
+)
+
+func seed(c *cli.Context, a *app) error {
+	seats := c.Int("seats")
+	if seats <= 0 || seats > demoMaxSeats {
+		return fmt.Errorf("seats must be between 1 and %d", demoMaxSeats)
+	}
+
+	loc, err := time.LoadLocation(demoTimezone)
+	if err != nil {
+		return err
+	}
+
+	today := time.Now().In(loc)
+	for _, demo := range demoEvents {
+		date := time.Date(today.Year(), today.Month(), today.Day()+demo.days, 20, 0, 0, 0, loc)
+
+		event, err := a.eventService.CreateEvent(c.Context, services.EventInput{
+			Name:     demo.name,
+			Date:     date,
+			Timezone: demoTimezone,
+			Venue:    demo.venue,
+			Category: demo.category,
+			Tags:     demo.tags,
+		})
+		if err != nil {
+			return err
+		}
+
+		tickets := make([]models.Ticket, seats)
+		for i := range tickets {
+			row := string(rune('A' + i/demoSeatsPerRow))
+			tickets[i] = models.Ticket{
+				SeatNumber: row + strconv.Itoa(i%demoSeatsPerRow+1),
+				Price:      demo.price,
+			}
+		}
+
+		_, _, err = a.ticketService.ImportTickets(c.Context, event.ID.Hex(), tickets, false)
+		if err != nil {
+			return err
+		}
+
+		for _, status := range []models.EventStatus{models.EventStatusPublished, models.EventStatusOnSale} {
+			event, err = a.eventService.TransitionEvent(c.Context, event.ID.Hex(), status)
+			if err != nil {
+				return err
+			}
+		}
+
+		fmt.Fprintf(c.App.Writer, "Created %s (%s) with %d seats\n", event.Name, event.ID.Hex(), seats)
+	}
+
+	return nil
+}
@@ -0,0 +1,251 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/enxg/skyticket/docs"
+	"github.com/enxg/skyticket/internal/controllers"
+	"github.com/enxg/skyticket/internal/logging"
+	"github.com/enxg/skyticket/internal/metrics"
+	"github.com/enxg/skyticket/internal/migrations"
+	"github.com/enxg/skyticket/internal/ratelimit"
+	"github.com/enxg/skyticket/internal/router"
+	"github.com/enxg/skyticket/internal/services"
+	"github.com/enxg/skyticket/internal/tracing"
+	"github.com/enxg/skyticket/pkg/validator"
+	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/fiber/v3/middleware/cors"
+	"github.com/rs/zerolog/log"
+	"github.com/urfave/cli/v2"
+)
+
+var serveCommand = &cli.Command{
+	Name:   "serve",
+	Usage:  "Start the HTTP server",
+	Action: serve,
+}
+
+const tracingFlushTimeout = 5 * time.Second
+
+var serve = withApp(func(c *cli.Context, a *app) error {
+	if sch := a.config.OpenAPI.Scheme; sch != "" {
+		docs.SwaggerInfo.Schemes = []string{sch}
+	}
+
+	if host := a.config.OpenAPI.Host; host != "" {
+		docs.SwaggerInfo.Host = host
+	}
+
+	shutdownTracing, err := tracing.Setup(c.Context, a.config.Tracing)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), tracingFlushTimeout)
+		defer cancel()
+
+		err := shutdownTracing(ctx)
+		if err != nil {
+			log.Error().Err(err).Msg("error flushing traces")
+		}
+	}()
+
+	applied, err := migrations.Run(c.Context, a.db)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range applied {
+		log.Info().Int("version", m.Version).Str("description", m.Description).Msg("applied migration")
+	}
+
+	if a.config.Queue.Secret == "" {
+		log.Warn().Msg("queue.secret is not set, queue tokens will only be valid on this instance until it restarts")
+	}
+
+	structValidator := validator.NewStructValidator()
+
+	eventController := controllers.NewEventController(a.eventService)
+	ticketController := controllers.NewTicketController(a.ticketService, structValidator)
+	reservationController := controllers.NewReservationController(a.reservationService)
+	checkInController := controllers.NewCheckInController(a.checkInService)
+	resaleController := controllers.NewResaleController(a.resaleService)
+	seriesController := controllers.NewSeriesController(a.seriesService)
+	reportController := controllers.NewReportController(a.reportService)
+	healthController := controllers.NewHealthController(a.healthService)
+	queueController := controllers.NewQueueController(a.queueService)
+
+	server := fiber.New(fiber.Config{
+		StructValidator: structValidator,
+		ErrorHandler:    router.ErrorHandler,
+		ReadTimeout:     a.config.Server.ReadTimeout,
+		WriteTimeout:    a.config.Server.WriteTimeout,
+		IdleTimeout:     a.config.Server.IdleTimeout,
+		TrustProxy:      len(a.config.Server.TrustedProxies) > 0,
+		TrustProxyConfig: fiber.TrustProxyConfig{
+			Proxies: a.config.Server.TrustedProxies,
+		},
+		ProxyHeader: a.config.Server.ProxyHeader,
+		// The proxy header may hold a comma-separated list of addresses;
+		// validation makes c.IP() return its first valid address.
+		EnableIPValidation: true,
+	})
+
+	server.Use(logging.Middleware())
+	server.Use(tracing.Middleware())
+	server.Use(metrics.Middleware())
+	server.Use(cors.New(cors.Config{
+		AllowOrigins: a.config.Server.CORSOrigins,
+		ExposeHeaders: []string{
+			logging.RequestIDHeader,
+			controllers.QueueTokenHeader,
+			fiber.HeaderRetryAfter,
+			ratelimit.HeaderLimit,
+			ratelimit.HeaderRemaining,
+			ratelimit.HeaderReset,
+		},
+	}))
+
+	var store ratelimit.Store
+	if a.config.RateLimit.Store == "mongo" {
+		store = ratelimit.NewMongoStore(a.db)
+	} else {
+		store = ratelimit.NewMemoryStore()
+	}
+
+	router.SetupRoutes(server, router.Controllers{
+		EventController:       eventController,
+		TicketController:      ticketController,
+		ReservationController: reservationController,
+		CheckInController:     checkInController,
+		ResaleController:      resaleController,
+		SeriesController:      seriesController,
+		ReportController:      reportController,
+		HealthController:      healthController,
+		QueueController:       queueController,
+	}, router.Middlewares{
+		RateLimit: ratelimit.Middleware(store,
+			ratelimit.Rule{Name: "ip", Rate: a.config.RateLimit.IP, Key: ratelimit.ByIP},
+			ratelimit.Rule{Name: "api_key", Rate: a.config.RateLimit.APIKey, Key: ratelimit.ByAPIKey},
+		),
+		ReservationRateLimit: ratelimit.Middleware(store,
+			ratelimit.Rule{Name: "reservation_ip", Rate: a.config.RateLimit.Reservations, Key: ratelimit.ByIP},
+			ratelimit.Rule{Name: "customer", Rate: a.config.RateLimit.Customer, Key: ratelimit.ByCustomer},
+		),
+	})
+
+	ctx, stop := signal.NotifyContext(c.Context, os.Interrupt, syscall.SIGTERM)
+
+	// Workers stop with ctx, which has to be cancelled before waiting for
+	// them in case Listen fails.
+	var workers sync.WaitGroup
+	defer func() {
+		stop()
+		workers.Wait()
+	}()
+
+	workers.Go(func() {
+		completePastEvents(ctx, a.eventService)
+	})
+
+	workers.Go(func() {
+		admitQueues(ctx, a.queueService)
+	})
+
+	// On SIGTERM readiness fails first, and the server keeps serving for the
+	// shutdown delay so that load balancers stop sending it traffic before
+	// it stops accepting connections.
+	shutdownCtx, shutdown := context.WithCancel(context.Background())
+	defer shutdown()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-shutdownCtx.Done():
+			return
+		}
+
+		a.healthService.StartDraining()
+		log.Info().Dur("delay", a.config.Server.ShutdownDelay).Msg("draining before shutdown")
+
+		select {
+		case <-time.After(a.config.Server.ShutdownDelay):
+		case <-shutdownCtx.Done():
+		}
+		shutdown()
+	}()
+
+	drained := make(chan error, 1)
+	server.Hooks().OnPostShutdown(func(err error) error {
+		drained <- err
+		return nil
+	})
+
+	err = server.Listen(a.config.Server.Address, fiber.ListenConfig{
+		GracefulContext: shutdownCtx,
+		ShutdownTimeout: a.config.Server.ShutdownTimeout,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Listen returns as soon as the listener is closed. Wait for in-flight
+	// requests to finish before the database connection is closed.
+	log.Info().Msg("shutting down")
+	err = <-drained
+	if err != nil {
+		return fmt.Errorf("shutting down: %w", err)
+	}
+
+	return nil
+})
+
+func completePastEvents(ctx context.Context, eventService services.EventService) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for {
+		n, err := eventService.CompletePastEvents(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Error().Err(err).Msg("error completing past events")
+		}
+
+		if n > 0 {
+			log.Info().Int64("count", n).Msg("marked past events as completed")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func admitQueues(ctx context.Context, queueService services.QueueService) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		n, err := queueService.AdmitDue(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Error().Err(err).Msg("error admitting queued users")
+		}
+
+		if n > 0 {
+			log.Debug().Int64("count", n).Msg("admitted queued users")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
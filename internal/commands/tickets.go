@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/enxg/skyticket/internal/imports"
+	"github.com/enxg/skyticket/internal/services"
+	"github.com/enxg/skyticket/pkg/validator"
+	"github.com/urfave/cli/v2"
+)
+
+var ticketsCommand = &cli.Command{
+	Name:  "tickets",
+	Usage: "Manage tickets",
+	Subcommands: []*cli.Command{
+		{
+			Name:      "import",
+			Usage:     "Import tickets from a CSV file of seat_number,price[,category[,section[,row,seat]]] rows",
+			ArgsUsage: "<event-id> <tickets.csv|->",
+			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name:  "dry-run",
+					Usage: "only validate the rows without creating tickets",
+				},
+			},
+			Action: withApp(importTickets),
+		},
+	},
+}
+
+func importTickets(c *cli.Context, a *app) error {
+	eventID := c.Args().Get(0)
+	path := c.Args().Get(1)
+	if eventID == "" || path == "" {
+		return fmt.Errorf("%w: event ID and CSV path", errMissingArgument)
+	}
+
+	in := io.Reader(os.Stdin)
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		in = f
+	}
+
+	rows, err := imports.ReadTickets(in, validator.NewStructValidator())
+	if err != nil {
+		return err
+	}
+
+	invalid := 0
+	for _, row := range rows {
+		for _, ve := range row.Errors {
+			fmt.Fprintf(c.App.ErrWriter, "line %d: %s\n", row.Line, ve.Error)
+		}
+		if len(row.Errors) > 0 {
+			invalid++
+		}
+	}
+
+	tickets, indexes := imports.ValidTickets(rows)
+	dryRun := c.Bool("dry-run")
+
+	created, seatErrs, err := a.ticketService.ImportTickets(c.Context, eventID, tickets, dryRun || invalid > 0)
+	if err != nil {
+		return err
+	}
+
+	for i, err := range seatErrs {
+		if errors.Is(err, services.ErrSeatNumberTaken) {
+			fmt.Fprintf(c.App.ErrWriter, "line %d: seat_number is already taken.\n", rows[indexes[i]].Line)
+			invalid++
+		}
+	}
+
+	if invalid > 0 {
+		return cli.Exit(fmt.Sprintf("%d of %d rows are invalid, no tickets were created", invalid, len(rows)), 1)
+	}
+
+	if dryRun {
+		fmt.Fprintf(c.App.Writer, "All %d rows are valid\n", len(rows))
+		return nil
+	}
+
+	fmt.Fprintf(c.App.Writer, "Created %d tickets\n", len(created))
+	return nil
+}
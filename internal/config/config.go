@@ -0,0 +1,348 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+type Config struct {
+	Server       ServerConfig       `yaml:"server"`
+	Database     DatabaseConfig     `yaml:"database"`
+	OpenAPI      OpenAPIConfig      `yaml:"openapi"`
+	Reservations ReservationsConfig `yaml:"reservations"`
+	Tracing      TracingConfig      `yaml:"tracing"`
+	RateLimit    RateLimitConfig    `yaml:"rate_limit"`
+	Queue        QueueConfig        `yaml:"queue"`
+}
+
+type ServerConfig struct {
+	Address      string        `yaml:"address"`
+	ReadTimeout  time.Duration `yaml:"read_timeout"`
+	WriteTimeout time.Duration `yaml:"write_timeout"`
+	IdleTimeout  time.Duration `yaml:"idle_timeout"`
+	// ShutdownDelay is how long the server keeps accepting requests after
+	// SIGTERM while readiness fails, so that load balancers stop routing
+	// traffic to it before it drains.
+	ShutdownDelay time.Duration `yaml:"shutdown_delay"`
+	// ShutdownTimeout bounds how long in-flight requests may take to finish
+	// after the shutdown delay. Zero waits indefinitely.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+	CORSOrigins     []string      `yaml:"cors_origins"`
+	// TrustedProxies lists the addresses or CIDR ranges of reverse proxies
+	// whose ProxyHeader is used as the client address, for example by the
+	// per-IP rate limit. Requests from anywhere else use the peer address.
+	TrustedProxies []string `yaml:"trusted_proxies"`
+	ProxyHeader    string   `yaml:"proxy_header"`
+}
+
+type DatabaseConfig struct {
+	URI            Secret        `yaml:"uri"`
+	Name           string        `yaml:"name"`
+	ConnectTimeout time.Duration `yaml:"connect_timeout"`
+}
+
+type OpenAPIConfig struct {
+	Scheme string `yaml:"scheme"`
+	Host   string `yaml:"host"`
+}
+
+type ReservationsConfig struct {
+	TransferCodeTTL time.Duration `yaml:"transfer_code_ttl"`
+}
+
+type TracingConfig struct {
+	// Exporter is one of none, otlp, stdout or file.
+	Exporter    string  `yaml:"exporter"`
+	File        string  `yaml:"file"`
+	SampleRatio float64 `yaml:"sample_ratio"`
+}
+
+type RateLimitConfig struct {
+	// Store is memory or mongo. Limits kept in MongoDB are shared by all
+	// instances.
+	Store    string `yaml:"store"`
+	IP       Rate   `yaml:"ip"`
+	APIKey   Rate   `yaml:"api_key"`
+	Customer Rate   `yaml:"customer"`
+	// Reservations limits reservation attempts per IP address on top of the
+	// general IP limit.
+	Reservations Rate `yaml:"reservations"`
+}
+
+type QueueConfig struct {
+	// Secret signs queue tokens. It must be shared by every instance; when
+	// empty a random secret is generated at startup.
+	Secret Secret `yaml:"secret"`
+	// AdmissionTTL is how long an admitted queue token may be used to make
+	// reservations.
+	AdmissionTTL time.Duration `yaml:"admission_ttl"`
+}
+
+// Secret is a string that is redacted whenever it is printed or marshalled.
+type Secret string
+
+const redacted = "[REDACTED]"
+
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return redacted
+}
+
+func (s Secret) MarshalYAML() (any, error) {
+	return s.String(), nil
+}
+
+var ErrMongoURINotSet = errors.New("database URI is not set, use MONGODB_URI or database.uri")
+
+// Default returns the configuration used when nothing else is set.
+func Default() Config {
+	return Config{
+		Server: ServerConfig{
+			Address:      ":3000",
+			ReadTimeout:  30 * time.Second,
+			WriteTimeout: 30 * time.Second,
+			IdleTimeout:  2 * time.Minute,
+			// Together shorter than the 30 second grace period most
+			// orchestrators give before killing the process.
+			ShutdownDelay:   5 * time.Second,
+			ShutdownTimeout: 20 * time.Second,
+			CORSOrigins:     []string{"*"},
+		},
+		Database: DatabaseConfig{
+			Name:           "skyticket",
+			ConnectTimeout: 10 * time.Second,
+		},
+		Reservations: ReservationsConfig{
+			TransferCodeTTL: 48 * time.Hour,
+		},
+		Tracing: TracingConfig{
+			Exporter:    "none",
+			SampleRatio: 1,
+		},
+		RateLimit: RateLimitConfig{
+			Store:        "memory",
+			IP:           Rate{Limit: 600, Period: time.Minute},
+			APIKey:       Rate{Limit: 1200, Period: time.Minute},
+			Customer:     Rate{Limit: 10, Period: time.Minute},
+			Reservations: Rate{Limit: 30, Period: time.Minute},
+		},
+		Queue: QueueConfig{
+			AdmissionTTL: 10 * time.Minute,
+		},
+	}
+}
+
+// Load builds the configuration from the defaults, the YAML or TOML file at
+// path (if any) and the environment, in that order. Flags are applied by the caller on
+// top of the result, which should then be validated.
+func Load(path string) (Config, error) {
+	cfg := Default()
+
+	if path != "" {
+		err := cfg.loadFile(path)
+		if err != nil {
+			return Config{}, err
+		}
+	}
+
+	err := cfg.loadEnv()
+	if err != nil {
+		return Config{}, err
+	}
+
+	return cfg, nil
+}
+
+// loadFile reads a YAML file, or a TOML file when path ends in .toml. Both
+// use the same setting names.
+func (c *Config) loadFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		data, err = tomlToYAML(data)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	err = c.decodeYAML(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	return nil
+}
+
+// tomlToYAML converts a TOML document to YAML so that it is decoded with the
+// same field names and strict unknown field checks.
+func tomlToYAML(data []byte) ([]byte, error) {
+	var doc map[string]any
+	err := toml.Unmarshal(data, &doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return yaml.Marshal(doc)
+}
+
+func (c *Config) decodeYAML(data []byte) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	err := decoder.Decode(c)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	return nil
+}
+
+// Set overrides a single setting given as "section.key=value", with the same
+// names and value formats as the configuration file. Values are read as YAML,
+// so lists can be written as "[a, b]".
+func (c *Config) Set(assignment string) error {
+	path, value, ok := strings.Cut(assignment, "=")
+	if !ok || path == "" {
+		return fmt.Errorf("%q: expected key=value", assignment)
+	}
+
+	node := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal([]byte(value), &doc); err == nil && len(doc.Content) == 1 {
+		node = doc.Content[0]
+	}
+
+	keys := strings.Split(path, ".")
+	for i := len(keys) - 1; i >= 0; i-- {
+		node = &yaml.Node{
+			Kind:    yaml.MappingNode,
+			Content: []*yaml.Node{{Kind: yaml.ScalarNode, Value: keys[i]}, node},
+		}
+	}
+
+	data, err := yaml.Marshal(node)
+	if err != nil {
+		return err
+	}
+
+	err = c.decodeYAML(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	return nil
+}
+
+// Validate reports every invalid setting at once.
+func (c Config) Validate() error {
+	var errs []error
+
+	if c.Server.Address == "" {
+		errs = append(errs, errors.New("server.address must not be empty"))
+	}
+
+	timeouts := []struct {
+		name  string
+		value time.Duration
+	}{
+		{"server.read_timeout", c.Server.ReadTimeout},
+		{"server.write_timeout", c.Server.WriteTimeout},
+		{"server.idle_timeout", c.Server.IdleTimeout},
+		{"server.shutdown_delay", c.Server.ShutdownDelay},
+		{"server.shutdown_timeout", c.Server.ShutdownTimeout},
+		{"database.connect_timeout", c.Database.ConnectTimeout},
+	}
+	for _, t := range timeouts {
+		if t.value < 0 {
+			errs = append(errs, fmt.Errorf("%s must not be negative", t.name))
+		}
+	}
+
+	if slices.Contains(c.Server.CORSOrigins, "") {
+		errs = append(errs, errors.New("server.cors_origins must not contain empty origins"))
+	}
+
+	if slices.Contains(c.Server.TrustedProxies, "") {
+		errs = append(errs, errors.New("server.trusted_proxies must not contain empty addresses"))
+	}
+
+	if len(c.Server.TrustedProxies) > 0 && c.Server.ProxyHeader == "" {
+		errs = append(errs, errors.New("server.proxy_header is required when server.trusted_proxies is set"))
+	}
+
+	if c.Database.URI == "" {
+		errs = append(errs, ErrMongoURINotSet)
+	}
+
+	if c.Database.Name == "" {
+		errs = append(errs, errors.New("database.name must not be empty"))
+	}
+
+	if c.OpenAPI.Scheme != "" && c.OpenAPI.Scheme != "http" && c.OpenAPI.Scheme != "https" {
+		errs = append(errs, errors.New("openapi.scheme must be http or https"))
+	}
+
+	if c.Reservations.TransferCodeTTL <= 0 {
+		errs = append(errs, errors.New("reservations.transfer_code_ttl must be positive"))
+	}
+
+	switch c.Tracing.Exporter {
+	case "none", "otlp", "stdout":
+	case "file":
+		if c.Tracing.File == "" {
+			errs = append(errs, errors.New("tracing.file is required for the file exporter"))
+		}
+	default:
+		errs = append(errs, errors.New("tracing.exporter must be none, otlp, stdout or file"))
+	}
+
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		errs = append(errs, errors.New("tracing.sample_ratio must be between 0 and 1"))
+	}
+
+	if c.RateLimit.Store != "memory" && c.RateLimit.Store != "mongo" {
+		errs = append(errs, errors.New("rate_limit.store must be memory or mongo"))
+	}
+
+	if c.Queue.AdmissionTTL <= 0 {
+		errs = append(errs, errors.New("queue.admission_ttl must be positive"))
+	}
+
+	return errors.Join(errs...)
+}
+
+// YAML returns the configuration in the file format with secrets redacted.
+func (c Config) YAML() ([]byte, error) {
+	var buf bytes.Buffer
+
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+
+	err := encoder.Encode(c)
+	if err != nil {
+		return nil, err
+	}
+
+	err = encoder.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
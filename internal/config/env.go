@@ -0,0 +1,153 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// loadEnv overrides the configuration with environment variables. Every
+// variable can also be read from a file by appending _FILE to its name, which
+// is how Docker and Kubernetes secrets are usually mounted.
+func (c *Config) loadEnv() error {
+	var errs []error
+
+	str := func(name string, dst *string) {
+		value, ok, err := lookupEnv(name)
+		if err != nil {
+			errs = append(errs, err)
+		} else if ok {
+			*dst = value
+		}
+	}
+
+	duration := func(name string, dst *time.Duration) {
+		value, ok, err := lookupEnv(name)
+		if err != nil {
+			errs = append(errs, err)
+			return
+		}
+		if !ok {
+			return
+		}
+
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			return
+		}
+		*dst = d
+	}
+
+	float := func(name string, dst *float64) {
+		value, ok, err := lookupEnv(name)
+		if err != nil {
+			errs = append(errs, err)
+			return
+		}
+		if !ok {
+			return
+		}
+
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			return
+		}
+		*dst = f
+	}
+
+	rate := func(name string, dst *Rate) {
+		value, ok, err := lookupEnv(name)
+		if err != nil {
+			errs = append(errs, err)
+			return
+		}
+		if !ok {
+			return
+		}
+
+		err = dst.UnmarshalText([]byte(value))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+
+	list := func(name string, dst *[]string) {
+		value, ok, err := lookupEnv(name)
+		if err != nil {
+			errs = append(errs, err)
+			return
+		}
+		if !ok {
+			return
+		}
+
+		items := strings.Split(value, ",")
+		for i := range items {
+			items[i] = strings.TrimSpace(items[i])
+		}
+		*dst = items
+	}
+
+	str("LISTEN_ADDRESS", &c.Server.Address)
+	duration("READ_TIMEOUT", &c.Server.ReadTimeout)
+	duration("WRITE_TIMEOUT", &c.Server.WriteTimeout)
+	duration("IDLE_TIMEOUT", &c.Server.IdleTimeout)
+	duration("SHUTDOWN_DELAY", &c.Server.ShutdownDelay)
+	duration("SHUTDOWN_TIMEOUT", &c.Server.ShutdownTimeout)
+	list("CORS_ORIGINS", &c.Server.CORSOrigins)
+	list("TRUSTED_PROXIES", &c.Server.TrustedProxies)
+	str("PROXY_HEADER", &c.Server.ProxyHeader)
+
+	uri := string(c.Database.URI)
+	str("MONGODB_URI", &uri)
+	c.Database.URI = Secret(uri)
+	str("MONGODB_DATABASE", &c.Database.Name)
+	duration("MONGODB_CONNECT_TIMEOUT", &c.Database.ConnectTimeout)
+
+	str("OPENAPI_SCHEME", &c.OpenAPI.Scheme)
+	str("OPENAPI_HOST", &c.OpenAPI.Host)
+
+	duration("TRANSFER_CODE_TTL", &c.Reservations.TransferCodeTTL)
+
+	str("TRACING_EXPORTER", &c.Tracing.Exporter)
+	str("TRACING_FILE", &c.Tracing.File)
+	float("TRACING_SAMPLE_RATIO", &c.Tracing.SampleRatio)
+
+	str("RATE_LIMIT_STORE", &c.RateLimit.Store)
+	rate("RATE_LIMIT_IP", &c.RateLimit.IP)
+	rate("RATE_LIMIT_API_KEY", &c.RateLimit.APIKey)
+	rate("RATE_LIMIT_CUSTOMER", &c.RateLimit.Customer)
+	rate("RATE_LIMIT_RESERVATIONS", &c.RateLimit.Reservations)
+
+	secret := string(c.Queue.Secret)
+	str("QUEUE_SECRET", &secret)
+	c.Queue.Secret = Secret(secret)
+	duration("QUEUE_ADMISSION_TTL", &c.Queue.AdmissionTTL)
+
+	return errors.Join(errs...)
+}
+
+// lookupEnv reads name, falling back to the contents of the file named by
+// name_FILE. Empty variables are treated as unset.
+func lookupEnv(name string) (string, bool, error) {
+	if value := os.Getenv(name); value != "" {
+		return value, true, nil
+	}
+
+	path := os.Getenv(name + "_FILE")
+	if path == "" {
+		return "", false, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false, fmt.Errorf("%s_FILE: %w", name, err)
+	}
+
+	return strings.TrimSpace(string(data)), true, nil
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Rate is a number of requests allowed per period, written as "limit/period",
+// e.g. "30/1m". A zero rate disables the limit.
+type Rate struct {
+	Limit  int
+	Period time.Duration
+}
+
+var errInvalidRate = errors.New(`rate must be "limit/period", e.g. "30/1m", or 0`)
+
+func (r Rate) Enabled() bool {
+	return r.Limit > 0
+}
+
+func (r Rate) MarshalText() ([]byte, error) {
+	if !r.Enabled() {
+		return []byte("0"), nil
+	}
+
+	return fmt.Appendf(nil, "%d/%s", r.Limit, r.Period), nil
+}
+
+func (r *Rate) UnmarshalText(text []byte) error {
+	value := strings.TrimSpace(string(text))
+	if value == "0" {
+		*r = Rate{}
+		return nil
+	}
+
+	limit, period, ok := strings.Cut(value, "/")
+	if !ok {
+		return errInvalidRate
+	}
+
+	l, err := strconv.Atoi(strings.TrimSpace(limit))
+	if err != nil || l < 0 {
+		return errInvalidRate
+	}
+
+	p, err := time.ParseDuration(strings.TrimSpace(period))
+	if err != nil || p <= 0 {
+		return errInvalidRate
+	}
+
+	*r = Rate{Limit: l, Period: p}
+	return nil
+}
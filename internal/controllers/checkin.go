@@ -0,0 +1,105 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/enxg/skyticket/internal/logging"
+	"github.com/enxg/skyticket/internal/requests"
+	"github.com/enxg/skyticket/internal/responses"
+	"github.com/enxg/skyticket/internal/services"
+	"github.com/gofiber/fiber/v3"
+)
+
+type CheckInController interface {
+	CreateCheckIn(c fiber.Ctx) error
+	GetCheckInStats(c fiber.Ctx) error
+}
+
+type checkInController struct {
+	checkInService services.CheckInService
+}
+
+func NewCheckInController(checkInService services.CheckInService) CheckInController {
+	return &checkInController{
+		checkInService: checkInService,
+	}
+}
+
+// CreateCheckIn godoc
+//
+//	@Summary		Check in a ticket
+//	@Description	Validate a scanned ticket token and admit its holder. Each ticket can only be checked in once.
+//	@Tags			Check-ins
+//	@Accept			json
+//	@Produce		json
+//	@Param			eventId	path		string							true	"Event ID"
+//	@Param			checkin	body		requests.CreateCheckInRequest	true	"Scanned ticket token"
+//	@Success		201		{object}	models.Reservation
+//	@Failure		400		{object}	responses.ValidationErrorResponse
+//	@Failure		404		{object}	responses.ErrorResponse				"Event not found / Invalid ticket token"
+//	@Failure		409		{object}	responses.AlreadyCheckedInResponse	"Ticket has already been checked in"
+//	@Failure		500		{object}	responses.ErrorResponse
+//	@Router			/events/{eventId}/checkins [post]
+func (ci *checkInController) CreateCheckIn(c fiber.Ctx) error {
+	var data requests.CreateCheckInRequest
+	err := c.Bind().Body(&data)
+	if err != nil {
+		return err
+	}
+
+	eventID := c.Params("eventId")
+
+	resp, err := ci.checkInService.CheckIn(c.Context(), eventID, data.Token)
+	if err != nil {
+		if errors.Is(err, services.ErrEventNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Event not found")
+		}
+
+		if errors.Is(err, services.ErrInvalidTicketToken) {
+			return fiber.NewError(fiber.StatusNotFound, "Invalid ticket token")
+		}
+
+		if errors.Is(err, services.ErrAlreadyCheckedIn) {
+			return c.Status(fiber.StatusConflict).JSON(responses.AlreadyCheckedInResponse{
+				Message:     "Ticket has already been checked in",
+				CheckedInAt: *resp.CheckedInAt,
+				RequestID:   logging.RequestID(c.Context()),
+			})
+		}
+
+		if errors.Is(err, services.ErrReservationNotActive) {
+			return fiber.NewError(fiber.StatusConflict, "Reservation is not active")
+		}
+
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(resp)
+}
+
+// GetCheckInStats godoc
+//
+//	@Summary		Get check-in counts
+//	@Description	Get the live number of reserved tickets and admitted attendees for an event
+//	@Tags			Check-ins
+//	@Accept			json
+//	@Produce		json
+//	@Param			eventId	path		string	true	"Event ID"
+//	@Success		200		{object}	models.CheckInStats
+//	@Failure		404		{object}	responses.ErrorResponse	"Event not found"
+//	@Failure		500		{object}	responses.ErrorResponse
+//	@Router			/events/{eventId}/checkins/stats [get]
+func (ci *checkInController) GetCheckInStats(c fiber.Ctx) error {
+	eventID := c.Params("eventId")
+
+	resp, err := ci.checkInService.GetCheckInStats(c.Context(), eventID)
+	if err != nil {
+		if errors.Is(err, services.ErrEventNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Event not found")
+		}
+
+		return err
+	}
+
+	return c.JSON(resp)
+}
@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/enxg/skyticket/internal/models"
+	"github.com/enxg/skyticket/internal/requests"
+)
+
+func toAddress(address *requests.AddressRequest) *models.Address {
+	if address == nil {
+		return nil
+	}
+
+	return &models.Address{
+		Street:     address.Street,
+		City:       address.City,
+		PostalCode: address.PostalCode,
+		Country:    address.Country,
+	}
+}
+
+func toGeoPoint(location *requests.LocationRequest) *models.GeoPoint {
+	if location == nil {
+		return nil
+	}
+
+	return models.NewGeoPoint(*location.Latitude, *location.Longitude)
+}
+
+func toSeatingRules(rules *requests.SeatingRulesRequest) *models.SeatingRules {
+	if rules == nil {
+		return nil
+	}
+
+	return &models.SeatingRules{
+		NoSingleGaps: rules.NoSingleGaps,
+	}
+}
+
+// parseLatLng parses a "lat,lng" pair. The value is expected to have passed
+// the latlong validator already.
+func parseLatLng(value string) (*models.GeoPoint, error) {
+	lat, lng, _ := strings.Cut(value, ",")
+
+	latitude, err := strconv.ParseFloat(strings.TrimSpace(lat), 64)
+	if err != nil {
+		return nil, err
+	}
+
+	longitude, err := strconv.ParseFloat(strings.TrimSpace(lng), 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return models.NewGeoPoint(latitude, longitude), nil
+}
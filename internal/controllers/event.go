@@ -1,10 +1,11 @@
 package controllers
 
 import (
+	"errors"
 	"time"
 
+	"github.com/enxg/skyticket/internal/models"
 	"github.com/enxg/skyticket/internal/requests"
-	"github.com/enxg/skyticket/internal/responses"
 	"github.com/enxg/skyticket/internal/services"
 	"github.com/gofiber/fiber/v3"
 )
@@ -13,8 +14,15 @@ type EventController interface {
 	CreateEvent(c fiber.Ctx) error
 	GetEventByID(c fiber.Ctx) error
 	GetAllEvents(c fiber.Ctx) error
+	SearchEvents(c fiber.Ctx) error
 	UpdateEvent(c fiber.Ctx) error
 	DeleteEvent(c fiber.Ctx) error
+	PublishEvent(c fiber.Ctx) error
+	StartEventSales(c fiber.Ctx) error
+	CancelEvent(c fiber.Ctx) error
+	CloneEvent(c fiber.Ctx) error
+	ExportEvent(c fiber.Ctx) error
+	ImportEvent(c fiber.Ctx) error
 }
 
 type eventController struct {
@@ -30,7 +38,7 @@ func NewEventController(eventService services.EventService) EventController {
 // CreateEvent godoc
 //
 //	@Summary		Create a new event
-//	@Description	Create a new event with the provided details
+//	@Description	Create a new event with the provided details. The date is either an RFC3339 timestamp or a local date-time in the event's timezone. Events default to UTC.
 //	@Tags			Events
 //	@Accept			json
 //	@Produce		json
@@ -46,18 +54,31 @@ func (s *eventController) CreateEvent(c fiber.Ctx) error {
 		return err
 	}
 
-	date, err := time.Parse(time.RFC3339, data.Date)
+	date, err := requests.ParseDate(data.Date, data.Timezone)
 	if err != nil {
+		if errors.Is(err, requests.ErrTimezoneRequired) {
+			return fiber.NewError(fiber.StatusBadRequest, "Timezone is required when the date has no UTC offset")
+		}
+
 		return err
 	}
 
 	if time.Now().After(date) {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
-			Message: "Event date cannot be in the past",
-		})
+		return fiber.NewError(fiber.StatusBadRequest, "Event date cannot be in the past")
 	}
 
-	resp, err := s.eventService.CreateEvent(c.Context(), data.Name, date, data.Venue)
+	resp, err := s.eventService.CreateEvent(c.Context(), services.EventInput{
+		Name:           data.Name,
+		Date:           date,
+		Timezone:       data.Timezone,
+		Venue:          data.Venue,
+		ResalePriceCap: data.ResalePriceCap,
+		Category:       data.Category,
+		Tags:           data.Tags,
+		Address:        toAddress(data.Address),
+		Location:       toGeoPoint(data.Location),
+		SeatingRules:   toSeatingRules(data.SeatingRules),
+	})
 	if err != nil {
 		return err
 	}
@@ -68,7 +89,7 @@ func (s *eventController) CreateEvent(c fiber.Ctx) error {
 // GetEventByID godoc
 //
 //	@Summary		Get event by ID
-//	@Description	Get details of an event by its ID
+//	@Description	Get details of an event by its ID. Draft events are not found.
 //	@Tags			Events
 //	@Accept			json
 //	@Produce		json
@@ -91,15 +112,67 @@ func (s *eventController) GetEventByID(c fiber.Ctx) error {
 // GetAllEvents godoc
 //
 //	@Summary		Get all events
-//	@Description	Retrieve a list of all events with their details
+//	@Description	Retrieve a list of all events with their details. Draft events are never listed; the events of a series, drafts included, are listed under the series.
 //	@Tags			Events
 //	@Accept			json
 //	@Produce		json
-//	@Success		200	{array}		models.Event
-//	@Failure		500	{object}	responses.ErrorResponse
+//	@Param			status		query		string	false	"Only return events with this status"	Enums(PUBLISHED, ON_SALE, SOLD_OUT, CANCELLED, COMPLETED)
+//	@Param			day			query		string	false	"Only return events on this calendar day in their local timezone (YYYY-MM-DD)"
+//	@Param			category	query		string	false	"Only return events in this category"
+//	@Param			tag			query		string	false	"Only return events with this tag"
+//	@Param			near		query		string	false	"Only return events within radius_km of this point (lat,lng), sorted by distance"
+//	@Param			radius_km	query		number	false	"Search radius in kilometers when near is set (default 25)"
+//	@Success		200			{array}		models.Event
+//	@Failure		400			{object}	responses.ValidationErrorResponse
+//	@Failure		500			{object}	responses.ErrorResponse
 //	@Router			/events [get]
 func (s *eventController) GetAllEvents(c fiber.Ctx) error {
-	resp, err := s.eventService.GetAllEvents(c.Context())
+	var query requests.GetEventsRequest
+	err := c.Bind().Query(&query)
+	if err != nil {
+		return err
+	}
+
+	var near *models.GeoPoint
+	if query.Near != "" {
+		near, err = parseLatLng(query.Near)
+		if err != nil {
+			return err
+		}
+	}
+
+	resp, err := s.eventService.GetAllEvents(c.Context(), models.EventStatus(query.Status), query.Day, query.Category, query.Tag, near, query.RadiusKm)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(resp)
+}
+
+// SearchEvents godoc
+//
+//	@Summary		Search events
+//	@Description	Full-text search over event names, tags, categories and venues. Results are ranked by relevance and come with counts by category, venue and local month. Draft events are never returned.
+//	@Tags			Events
+//	@Accept			json
+//	@Produce		json
+//	@Param			q			query		string	true	"Search text"
+//	@Param			category	query		string	false	"Only return events in this category"
+//	@Param			venue		query		string	false	"Only return events at this venue"
+//	@Param			month		query		string	false	"Only return events in this local month (YYYY-MM)"
+//	@Param			limit		query		int		false	"Maximum number of results (default 20, max 100)"
+//	@Success		200			{object}	models.EventSearchResult
+//	@Failure		400			{object}	responses.ValidationErrorResponse
+//	@Failure		500			{object}	responses.ErrorResponse
+//	@Router			/events/search [get]
+func (s *eventController) SearchEvents(c fiber.Ctx) error {
+	var query requests.SearchEventsRequest
+	err := c.Bind().Query(&query)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.eventService.SearchEvents(c.Context(), query.Query, query.Category, query.Venue, query.Month, query.Limit)
 	if err != nil {
 		return err
 	}
@@ -132,19 +205,32 @@ func (s *eventController) UpdateEvent(c fiber.Ctx) error {
 
 	var date time.Time
 	if data.Date != "" {
-		date, err = time.Parse(time.RFC3339, data.Date)
+		date, err = requests.ParseDate(data.Date, data.Timezone)
 		if err != nil {
+			if errors.Is(err, requests.ErrTimezoneRequired) {
+				return fiber.NewError(fiber.StatusBadRequest, "Timezone is required when the date has no UTC offset")
+			}
+
 			return err
 		}
 	}
 
 	if !date.IsZero() && time.Now().After(date) {
-		return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
-			Message: "Event date cannot be in the past",
-		})
+		return fiber.NewError(fiber.StatusBadRequest, "Event date cannot be in the past")
 	}
 
-	resp, err := s.eventService.UpdateEvent(c.Context(), id, data.Name, date, data.Venue)
+	resp, err := s.eventService.UpdateEvent(c.Context(), id, services.EventInput{
+		Name:           data.Name,
+		Date:           date,
+		Timezone:       data.Timezone,
+		Venue:          data.Venue,
+		ResalePriceCap: data.ResalePriceCap,
+		Category:       data.Category,
+		Tags:           data.Tags,
+		Address:        toAddress(data.Address),
+		Location:       toGeoPoint(data.Location),
+		SeatingRules:   toSeatingRules(data.SeatingRules),
+	})
 	if err != nil {
 		return err
 	}
@@ -173,3 +259,179 @@ func (s *eventController) DeleteEvent(c fiber.Ctx) error {
 
 	return c.SendStatus(fiber.StatusNoContent)
 }
+
+// PublishEvent godoc
+//
+//	@Summary		Publish an event
+//	@Description	Announce a draft event. Published events are visible in the event list but cannot be booked yet.
+//	@Tags			Events
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Event ID"
+//	@Success		200	{object}	models.Event
+//	@Failure		400	{object}	responses.ErrorResponse	"Event date has already passed"
+//	@Failure		404	{object}	responses.ErrorResponse
+//	@Failure		409	{object}	responses.ErrorResponse	"Invalid event status transition"
+//	@Failure		500	{object}	responses.ErrorResponse
+//	@Router			/events/{id}/publish [post]
+func (s *eventController) PublishEvent(c fiber.Ctx) error {
+	return s.transitionEvent(c, models.EventStatusPublished)
+}
+
+// StartEventSales godoc
+//
+//	@Summary		Start ticket sales
+//	@Description	Put a published event on sale so that tickets can be reserved. The event moves to SOLD_OUT and back automatically as tickets run out or become available again.
+//	@Tags			Events
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Event ID"
+//	@Success		200	{object}	models.Event
+//	@Failure		400	{object}	responses.ErrorResponse	"Event date has already passed"
+//	@Failure		404	{object}	responses.ErrorResponse
+//	@Failure		409	{object}	responses.ErrorResponse	"Invalid event status transition"
+//	@Failure		500	{object}	responses.ErrorResponse
+//	@Router			/events/{id}/on-sale [post]
+func (s *eventController) StartEventSales(c fiber.Ctx) error {
+	return s.transitionEvent(c, models.EventStatusOnSale)
+}
+
+// CancelEvent godoc
+//
+//	@Summary		Cancel an event
+//	@Description	Cancel an event that has not been completed yet. Cancelled events cannot be booked.
+//	@Tags			Events
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Event ID"
+//	@Success		200	{object}	models.Event
+//	@Failure		400	{object}	responses.ErrorResponse	"Event date has already passed"
+//	@Failure		404	{object}	responses.ErrorResponse
+//	@Failure		409	{object}	responses.ErrorResponse	"Invalid event status transition"
+//	@Failure		500	{object}	responses.ErrorResponse
+//	@Router			/events/{id}/cancel [post]
+func (s *eventController) CancelEvent(c fiber.Ctx) error {
+	return s.transitionEvent(c, models.EventStatusCancelled)
+}
+
+// CloneEvent godoc
+//
+//	@Summary		Clone an event
+//	@Description	Create a new draft event on a different date from an existing one. The clone keeps the source event's timezone unless a new one is given. All tickets are copied as available with the same seat numbers and prices; reservations are not copied.
+//	@Tags			Events
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"Event ID"
+//	@Param			event	body		requests.CloneEventRequest	true	"New date and optional overrides"
+//	@Success		201		{object}	models.Event
+//	@Failure		400		{object}	responses.ValidationErrorResponse
+//	@Failure		404		{object}	responses.ErrorResponse
+//	@Failure		500		{object}	responses.ErrorResponse
+//	@Router			/events/{id}/clone [post]
+func (s *eventController) CloneEvent(c fiber.Ctx) error {
+	id := c.Params("id")
+
+	var data requests.CloneEventRequest
+	err := c.Bind().Body(&data)
+	if err != nil {
+		return err
+	}
+
+	date, err := requests.ParseDate(data.Date, data.Timezone)
+	if err != nil {
+		if errors.Is(err, requests.ErrTimezoneRequired) {
+			return fiber.NewError(fiber.StatusBadRequest, "Timezone is required when the date has no UTC offset")
+		}
+
+		return err
+	}
+
+	if time.Now().After(date) {
+		return fiber.NewError(fiber.StatusBadRequest, "Event date cannot be in the past")
+	}
+
+	resp, err := s.eventService.CloneEvent(c.Context(), id, date, data.Timezone, data.Name, data.Venue)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(resp)
+}
+
+func (s *eventController) transitionEvent(c fiber.Ctx, status models.EventStatus) error {
+	id := c.Params("id")
+
+	resp, err := s.eventService.TransitionEvent(c.Context(), id, status)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidEventTransition) {
+			return fiber.NewError(fiber.StatusConflict, "Invalid event status transition")
+		}
+
+		if errors.Is(err, services.ErrEventAlreadyPassed) {
+			return fiber.NewError(fiber.StatusBadRequest, "Event date has already passed")
+		}
+
+		return err
+	}
+
+	return c.JSON(resp)
+}
+
+// ExportEvent godoc
+//
+//	@Summary		Export an event archive
+//	@Description	Download an event together with its tickets and reservations as a versioned JSON archive that can be imported into another environment.
+//	@Tags			Events
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Event ID"
+//	@Success		200	{object}	models.EventArchive
+//	@Failure		404	{object}	responses.ErrorResponse
+//	@Failure		500	{object}	responses.ErrorResponse
+//	@Router			/events/{id}/export [get]
+func (s *eventController) ExportEvent(c fiber.Ctx) error {
+	id := c.Params("id")
+
+	resp, err := s.eventService.ExportEvent(c.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	c.Attachment("event-" + resp.Event.ID.Hex() + ".json")
+	return c.JSON(resp)
+}
+
+// ImportEvent godoc
+//
+//	@Summary		Import an event archive
+//	@Description	Restore an event archive created by the export endpoint. The event, its tickets and its reservations are created with new IDs, and their relationships are kept. Ticket availability and remaining general admission places are recomputed from the reservations; archives whose reservations hold more places than a ticket has are rejected. The imported event is not attached to any series.
+//	@Tags			Events
+//	@Accept			json
+//	@Produce		json
+//	@Param			archive	body		models.EventArchive	true	"Event archive"
+//	@Success		201		{object}	models.Event
+//	@Failure		400		{object}	responses.ErrorResponse	"Unsupported archive version / Invalid archive"
+//	@Failure		500		{object}	responses.ErrorResponse
+//	@Router			/events/import [post]
+func (s *eventController) ImportEvent(c fiber.Ctx) error {
+	var data models.EventArchive
+	err := c.Bind().Body(&data)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.eventService.ImportEvent(c.Context(), data)
+	if err != nil {
+		if errors.Is(err, services.ErrUnsupportedArchiveVersion) {
+			return fiber.NewError(fiber.StatusBadRequest, "Unsupported archive version")
+		}
+
+		if errors.Is(err, services.ErrInvalidArchive) {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid archive")
+		}
+
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(resp)
+}
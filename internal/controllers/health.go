@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/enxg/skyticket/internal/logging"
+	"github.com/enxg/skyticket/internal/responses"
+	"github.com/enxg/skyticket/internal/services"
+	"github.com/gofiber/fiber/v3"
+)
+
+type HealthController interface {
+	GetLiveness(c fiber.Ctx) error
+	GetReadiness(c fiber.Ctx) error
+}
+
+type healthController struct {
+	healthService services.HealthService
+}
+
+func NewHealthController(healthService services.HealthService) HealthController {
+	return &healthController{
+		healthService: healthService,
+	}
+}
+
+// GetLiveness godoc
+//
+//	@Summary		Liveness probe
+//	@Description	Report that the process is running. This endpoint does not touch the database.
+//	@Tags			Health
+//	@Produce		json
+//	@Success		200	{object}	responses.HealthResponse
+//	@Router			/healthz [get]
+func (h *healthController) GetLiveness(c fiber.Ctx) error {
+	return c.JSON(responses.HealthResponse{
+		Status: "ok",
+	})
+}
+
+// GetReadiness godoc
+//
+//	@Summary		Readiness probe
+//	@Description	Report whether the service can handle traffic. The database must be reachable and support transactions, which requires a replica set or a sharded cluster. Readiness fails as soon as the server receives SIGTERM.
+//	@Tags			Health
+//	@Produce		json
+//	@Success		200	{object}	responses.HealthResponse
+//	@Failure		503	{object}	responses.ErrorResponse	"Database is unavailable or does not support transactions / Server is shutting down"
+//	@Router			/readyz [get]
+func (h *healthController) GetReadiness(c fiber.Ctx) error {
+	err := h.healthService.CheckReadiness(c.Context())
+	if err != nil {
+		logging.FromContext(c.Context()).Warn().Err(err).Msg("readiness check failed")
+
+		message := "Database is unavailable"
+		if errors.Is(err, services.ErrTransactionsUnsupported) {
+			message = "Database does not support transactions"
+		} else if errors.Is(err, services.ErrShuttingDown) {
+			message = "Server is shutting down"
+		}
+
+		return fiber.NewError(fiber.StatusServiceUnavailable, message)
+	}
+
+	return c.JSON(responses.HealthResponse{
+		Status: "ok",
+	})
+}
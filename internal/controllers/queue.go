@@ -0,0 +1,294 @@
+package controllers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/enxg/skyticket/internal/logging"
+	"github.com/enxg/skyticket/internal/models"
+	"github.com/enxg/skyticket/internal/requests"
+	"github.com/enxg/skyticket/internal/services"
+	"github.com/gofiber/fiber/v3"
+)
+
+type QueueController interface {
+	ConfigureQueue(c fiber.Ctx) error
+	GetQueue(c fiber.Ctx) error
+	JoinQueue(c fiber.Ctx) error
+	GetQueuePosition(c fiber.Ctx) error
+	StreamQueuePosition(c fiber.Ctx) error
+	RequireAdmission(c fiber.Ctx) error
+}
+
+const QueueTokenHeader = "X-Queue-Token"
+
+const (
+	queueStreamInterval = 2 * time.Second
+	// queueStreamDuration is kept below the server's write timeout. Clients
+	// using EventSource reconnect automatically when the stream ends.
+	queueStreamDuration = 20 * time.Second
+	queueStreamRetry    = 2 * time.Second
+)
+
+type queueController struct {
+	queueService services.QueueService
+}
+
+func NewQueueController(queueService services.QueueService) QueueController {
+	return &queueController{
+		queueService: queueService,
+	}
+}
+
+// ConfigureQueue godoc
+//
+//	@Summary		Configure the virtual queue of an event
+//	@Description	Enable, disable or tune the virtual queue of an event. While the queue is enabled, reservations for the event require an admitted queue token. Every interval_seconds the next batch_size positions in the queue are admitted. Disabling the queue lets every request through again; positions are kept if it is enabled later.
+//	@Tags			Queue
+//	@Accept			json
+//	@Produce		json
+//	@Param			eventId	path		string							true	"Event ID"
+//	@Param			queue	body		requests.ConfigureQueueRequest	true	"Queue settings"
+//	@Success		200		{object}	models.EventQueue
+//	@Failure		400		{object}	responses.ValidationErrorResponse
+//	@Failure		404		{object}	responses.ErrorResponse	"Event not found"
+//	@Failure		500		{object}	responses.ErrorResponse
+//	@Router			/events/{eventId}/queue [put]
+func (q *queueController) ConfigureQueue(c fiber.Ctx) error {
+	var data requests.ConfigureQueueRequest
+	err := c.Bind().Body(&data)
+	if err != nil {
+		return err
+	}
+
+	eventID := c.Params("eventId")
+
+	resp, err := q.queueService.ConfigureQueue(c.Context(), eventID, data.Enabled, data.BatchSize, data.IntervalSeconds)
+	if err != nil {
+		if errors.Is(err, services.ErrEventNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Event not found")
+		}
+
+		return err
+	}
+
+	return c.JSON(resp)
+}
+
+// GetQueue godoc
+//
+//	@Summary		Get the virtual queue of an event
+//	@Description	Get the settings and progress of the virtual queue of an event, including the number of people still waiting.
+//	@Tags			Queue
+//	@Accept			json
+//	@Produce		json
+//	@Param			eventId	path		string	true	"Event ID"
+//	@Success		200		{object}	models.EventQueue
+//	@Failure		404		{object}	responses.ErrorResponse	"Queue not found"
+//	@Failure		500		{object}	responses.ErrorResponse
+//	@Router			/events/{eventId}/queue [get]
+func (q *queueController) GetQueue(c fiber.Ctx) error {
+	eventID := c.Params("eventId")
+
+	resp, err := q.queueService.GetQueue(c.Context(), eventID)
+	if err != nil {
+		if errors.Is(err, services.ErrQueueNotEnabled) {
+			return fiber.NewError(fiber.StatusNotFound, "Queue not found")
+		}
+
+		return err
+	}
+
+	return c.JSON(resp)
+}
+
+// JoinQueue godoc
+//
+//	@Summary		Join the virtual queue of an event
+//	@Description	Take the next position in the virtual queue of an event. The returned token identifies the position; send it in the X-Queue-Token header to check the position and, once admitted, to make reservations. Admission lasts for a limited time, shown in expires_at.
+//	@Tags			Queue
+//	@Accept			json
+//	@Produce		json
+//	@Param			eventId	path		string	true	"Event ID"
+//	@Success		201		{object}	models.QueuePosition
+//	@Failure		400		{object}	responses.ErrorResponse	"Event date has already passed / Event is cancelled"
+//	@Failure		404		{object}	responses.ErrorResponse	"Event not found"
+//	@Failure		409		{object}	responses.ErrorResponse	"Event has no enabled queue"
+//	@Failure		429		{object}	responses.ErrorResponse	"Too many requests, retry after the number of seconds in the Retry-After header"
+//	@Failure		500		{object}	responses.ErrorResponse
+//	@Router			/events/{eventId}/queue/join [post]
+func (q *queueController) JoinQueue(c fiber.Ctx) error {
+	eventID := c.Params("eventId")
+
+	resp, err := q.queueService.JoinQueue(c.Context(), eventID)
+	if err != nil {
+		if errors.Is(err, services.ErrEventNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Event not found")
+		}
+
+		if errors.Is(err, services.ErrEventAlreadyPassed) {
+			return fiber.NewError(fiber.StatusBadRequest, "Event date has already passed")
+		}
+
+		if errors.Is(err, services.ErrEventCancelled) {
+			return fiber.NewError(fiber.StatusBadRequest, "Event is cancelled")
+		}
+
+		if errors.Is(err, services.ErrQueueNotEnabled) {
+			return fiber.NewError(fiber.StatusConflict, "Event has no enabled queue")
+		}
+
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(resp)
+}
+
+// GetQueuePosition godoc
+//
+//	@Summary		Get a position in the virtual queue
+//	@Description	Get the position, the number of people ahead and the estimated wait of a queue token. Clients are expected to poll this endpoint every few seconds, or to use the stream endpoint instead.
+//	@Tags			Queue
+//	@Accept			json
+//	@Produce		json
+//	@Param			eventId			path		string	true	"Event ID"
+//	@Param			X-Queue-Token	header		string	false	"Queue token"
+//	@Param			token			query		string	false	"Queue token, for clients that cannot set headers"
+//	@Success		200				{object}	models.QueuePosition
+//	@Failure		401				{object}	responses.ErrorResponse	"Queue token is required / Invalid queue token"
+//	@Failure		404				{object}	responses.ErrorResponse	"Queue not found"
+//	@Failure		500				{object}	responses.ErrorResponse
+//	@Router			/events/{eventId}/queue/position [get]
+func (q *queueController) GetQueuePosition(c fiber.Ctx) error {
+	eventID := c.Params("eventId")
+
+	resp, err := q.queueService.GetPosition(c.Context(), eventID, queueToken(c))
+	if err != nil {
+		return queueError(c, err)
+	}
+
+	return c.JSON(resp)
+}
+
+// StreamQueuePosition godoc
+//
+//	@Summary		Stream a position in the virtual queue
+//	@Description	Stream the position of a queue token as server-sent events. A "position" event carrying a models.QueuePosition is sent every few seconds until the token is admitted. The stream is closed periodically; EventSource clients reconnect on their own.
+//	@Tags			Queue
+//	@Produce		text/event-stream
+//	@Param			eventId			path		string					true	"Event ID"
+//	@Param			X-Queue-Token	header		string					false	"Queue token"
+//	@Param			token			query		string					false	"Queue token, for clients that cannot set headers"
+//	@Success		200				{string}	string					"Stream of position events"
+//	@Failure		401				{object}	responses.ErrorResponse	"Queue token is required / Invalid queue token"
+//	@Failure		404				{object}	responses.ErrorResponse	"Queue not found"
+//	@Failure		500				{object}	responses.ErrorResponse
+//	@Router			/events/{eventId}/queue/position/stream [get]
+func (q *queueController) StreamQueuePosition(c fiber.Ctx) error {
+	eventID := c.Params("eventId")
+	token := queueToken(c)
+
+	// Fail with a regular response for invalid tokens, before the stream
+	// starts.
+	position, err := q.queueService.GetPosition(c.Context(), eventID, token)
+	if err != nil {
+		return queueError(c, err)
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+
+	// The stream is written after the handler returns, when c may already
+	// have been reused for another request.
+	logger := logging.FromContext(c.Context())
+	queueService := q.queueService
+
+	return c.SendStreamWriter(func(w *bufio.Writer) {
+		ctx, cancel := context.WithTimeout(context.Background(), queueStreamDuration)
+		defer cancel()
+
+		ticker := time.NewTicker(queueStreamInterval)
+		defer ticker.Stop()
+
+		fmt.Fprintf(w, "retry: %d\n\n", queueStreamRetry.Milliseconds())
+
+		for {
+			data, err := json.Marshal(position)
+			if err != nil {
+				logger.Error().Err(err).Msg("error encoding queue position")
+				return
+			}
+
+			fmt.Fprintf(w, "event: position\ndata: %s\n\n", data)
+
+			// Flush fails once the client has gone away.
+			err = w.Flush()
+			if err != nil || position.Status == models.QueueEntryStatusAdmitted {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+
+			position, err = queueService.GetPosition(ctx, eventID, token)
+			if err != nil {
+				if ctx.Err() == nil {
+					logger.Error().Err(err).Str("event_id", eventID).Msg("error streaming queue position")
+				}
+				return
+			}
+		}
+	})
+}
+
+// RequireAdmission rejects reservation requests for events with an enabled
+// queue unless they carry an admitted queue token.
+func (q *queueController) RequireAdmission(c fiber.Ctx) error {
+	eventID := c.Params("eventId")
+
+	err := q.queueService.CheckAdmission(c.Context(), eventID, queueToken(c))
+	if err != nil {
+		if errors.Is(err, services.ErrNotAdmitted) {
+			return fiber.NewError(fiber.StatusForbidden, "Queue token has not been admitted yet")
+		}
+
+		if errors.Is(err, services.ErrAdmissionExpired) {
+			return fiber.NewError(fiber.StatusForbidden, "Queue admission has expired, join the queue again")
+		}
+
+		return queueError(c, err)
+	}
+
+	return c.Next()
+}
+
+func queueToken(c fiber.Ctx) string {
+	if token := c.Get(QueueTokenHeader); token != "" {
+		return token
+	}
+	return c.Query("token")
+}
+
+func queueError(c fiber.Ctx, err error) error {
+	if errors.Is(err, services.ErrQueueTokenRequired) {
+		return fiber.NewError(fiber.StatusUnauthorized, "Queue token is required, join the queue first")
+	}
+
+	if errors.Is(err, services.ErrInvalidQueueToken) {
+		return fiber.NewError(fiber.StatusUnauthorized, "Invalid queue token")
+	}
+
+	if errors.Is(err, services.ErrQueueNotEnabled) {
+		return fiber.NewError(fiber.StatusNotFound, "Queue not found")
+	}
+
+	return err
+}
@@ -0,0 +1,269 @@
+package controllers
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"iter"
+	"strconv"
+	"time"
+
+	"github.com/enxg/skyticket/internal/logging"
+	"github.com/enxg/skyticket/internal/models"
+	"github.com/enxg/skyticket/internal/requests"
+	"github.com/enxg/skyticket/internal/services"
+	"github.com/gofiber/fiber/v3"
+	"github.com/xuri/excelize/v2"
+)
+
+type ReportController interface {
+	GetEventStats(c fiber.Ctx) error
+	GetSalesSummary(c fiber.Ctx) error
+	ExportManifest(c fiber.Ctx) error
+}
+
+const (
+	mimeTextCSV = "text/csv"
+	mimeXLSX    = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+const manifestDateLayout = "2006-01-02 15:04"
+
+var manifestHeader = []string{"Seat Number", "Category", "Type", "Places", "Customer Name", "Reservation Date", "Status"}
+
+type reportController struct {
+	reportService services.ReportService
+}
+
+func NewReportController(reportService services.ReportService) ReportController {
+	return &reportController{
+		reportService: reportService,
+	}
+}
+
+// GetEventStats godoc
+//
+//	@Summary		Get sales statistics for an event
+//	@Description	Get ticket counts by status, gross revenue and average price of sold tickets, sell-through percentage, cancellations, and reservations per day in the event's local timezone. Monetary values are in minor units.
+//	@Tags			Reports
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Event ID"
+//	@Success		200	{object}	models.EventStats
+//	@Failure		404	{object}	responses.ErrorResponse	"Event not found"
+//	@Failure		500	{object}	responses.ErrorResponse
+//	@Router			/events/{id}/stats [get]
+func (r *reportController) GetEventStats(c fiber.Ctx) error {
+	id := c.Params("id")
+
+	resp, err := r.reportService.GetEventStats(c.Context(), id)
+	if err != nil {
+		if errors.Is(err, services.ErrEventNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Event not found")
+		}
+
+		return err
+	}
+
+	return c.JSON(resp)
+}
+
+// GetSalesSummary godoc
+//
+//	@Summary		Get a sales summary across events
+//	@Description	Get ticket sales, gross revenue and cancellations for every non-draft event taking place between the given UTC days (inclusive), with totals. Monetary values are in minor units.
+//	@Tags			Reports
+//	@Accept			json
+//	@Produce		json
+//	@Param			from	query		string	true	"First day of the range (YYYY-MM-DD)"
+//	@Param			to		query		string	true	"Last day of the range (YYYY-MM-DD)"
+//	@Success		200		{object}	models.SalesSummary
+//	@Failure		400		{object}	responses.ValidationErrorResponse
+//	@Failure		500		{object}	responses.ErrorResponse
+//	@Router			/reports/summary [get]
+func (r *reportController) GetSalesSummary(c fiber.Ctx) error {
+	var query requests.GetSalesSummaryRequest
+	err := c.Bind().Query(&query)
+	if err != nil {
+		return err
+	}
+
+	from, err := time.Parse(time.DateOnly, query.From)
+	if err != nil {
+		return err
+	}
+
+	to, err := time.Parse(time.DateOnly, query.To)
+	if err != nil {
+		return err
+	}
+
+	resp, err := r.reportService.GetSalesSummary(c.Context(), from, to)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidDateRange) {
+			return fiber.NewError(fiber.StatusBadRequest, "The end of the range cannot be before its start")
+		}
+
+		return err
+	}
+
+	return c.JSON(resp)
+}
+
+// ExportManifest godoc
+//
+//	@Summary		Export the attendee manifest of an event
+//	@Description	Download the list of attendees of an event with their seat number, ticket category and type, number of places admitted, name, reservation date and status. The format is chosen with the Accept header: CSV (text/csv, the default) or XLSX. Reservation dates are in the event's local timezone. Cancelled reservations are not included.
+//	@Tags			Reports
+//	@Produce		text/csv
+//	@Produce		application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+//	@Param			id		path		string	true	"Event ID"
+//	@Param			sort	query		string	false	"Sort order (default seat)"	Enums(seat, name)
+//	@Success		200		{file}		file
+//	@Failure		400		{object}	responses.ValidationErrorResponse
+//	@Failure		404		{object}	responses.ErrorResponse	"Event not found"
+//	@Failure		406		{object}	responses.ErrorResponse	"Unsupported format"
+//	@Failure		500		{object}	responses.ErrorResponse
+//	@Router			/events/{id}/manifest [get]
+func (r *reportController) ExportManifest(c fiber.Ctx) error {
+	id := c.Params("id")
+
+	var query requests.GetManifestRequest
+	err := c.Bind().Query(&query)
+	if err != nil {
+		return err
+	}
+
+	format := c.Accepts(mimeTextCSV, mimeXLSX)
+	if format == "" {
+		return fiber.NewError(fiber.StatusNotAcceptable, "Manifests are available as text/csv or "+mimeXLSX)
+	}
+
+	manifest, err := r.reportService.ExportManifest(c.Context(), id, query.Sort)
+	if err != nil {
+		if errors.Is(err, services.ErrEventNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Event not found")
+		}
+
+		return err
+	}
+
+	write := writeManifestCSV
+	extension := "csv"
+	if format == mimeXLSX {
+		write = writeManifestXLSX
+		extension = "xlsx"
+	}
+
+	c.Set(fiber.HeaderContentType, format)
+	c.Attachment(fmt.Sprintf("manifest-%s.%s", manifest.Event.ID.Hex(), extension))
+
+	// The stream is written after the handler returns, when c may already
+	// have been reused for another request.
+	logger := logging.FromContext(c.Context())
+
+	return c.SendStreamWriter(func(w *bufio.Writer) {
+		err := write(w, manifest.Entries, manifest.Location)
+		if err != nil {
+			logger.Error().Err(err).Str("event_id", manifest.Event.ID.Hex()).Msg("error writing manifest")
+
+			// Writing may have failed before the entries were read, and
+			// abandoning them is what closes the cursor.
+			for range manifest.Entries {
+				break
+			}
+		}
+	})
+}
+
+func writeManifestCSV(w *bufio.Writer, entries iter.Seq2[models.ManifestEntry, error], loc *time.Location) error {
+	cw := csv.NewWriter(w)
+
+	err := cw.Write(manifestHeader)
+	if err != nil {
+		return err
+	}
+
+	for entry, err := range entries {
+		if err != nil {
+			return err
+		}
+
+		err = cw.Write([]string{
+			entry.SeatNumber,
+			entry.Category,
+			string(entry.TicketType),
+			strconv.Itoa(entry.Places),
+			entry.CustomerName,
+			entry.ReservationDate.In(loc).Format(manifestDateLayout),
+			string(entry.Status),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeManifestXLSX(w *bufio.Writer, entries iter.Seq2[models.ManifestEntry, error], loc *time.Location) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	sheet := f.GetSheetName(0)
+	sw, err := f.NewStreamWriter(sheet)
+	if err != nil {
+		return err
+	}
+
+	header := make([]any, len(manifestHeader))
+	for i, h := range manifestHeader {
+		header[i] = h
+	}
+
+	err = sw.SetRow("A1", header)
+	if err != nil {
+		return err
+	}
+
+	row := 2
+	for entry, err := range entries {
+		if err != nil {
+			return err
+		}
+
+		cell, err := excelize.CoordinatesToCellName(1, row)
+		if err != nil {
+			return err
+		}
+
+		err = sw.SetRow(cell, []any{
+			entry.SeatNumber,
+			entry.Category,
+			string(entry.TicketType),
+			entry.Places,
+			entry.CustomerName,
+			entry.ReservationDate.In(loc).Format(manifestDateLayout),
+			string(entry.Status),
+		})
+		if err != nil {
+			return err
+		}
+
+		row++
+	}
+
+	err = sw.Flush()
+	if err != nil {
+		return err
+	}
+
+	_, err = f.WriteTo(w)
+	if err != nil {
+		return err
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,269 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/enxg/skyticket/internal/requests"
+	"github.com/enxg/skyticket/internal/services"
+	"github.com/gofiber/fiber/v3"
+)
+
+type ResaleController interface {
+	CreateListing(c fiber.Ctx) error
+	GetListingByID(c fiber.Ctx) error
+	GetAllListings(c fiber.Ctx) error
+	CancelListing(c fiber.Ctx) error
+	PurchaseListing(c fiber.Ctx) error
+	GetAllPayouts(c fiber.Ctx) error
+}
+
+type resaleController struct {
+	resaleService services.ResaleService
+}
+
+func NewResaleController(resaleService services.ResaleService) ResaleController {
+	return &resaleController{
+		resaleService: resaleService,
+	}
+}
+
+// CreateListing godoc
+//
+//	@Summary		List a ticket for resale
+//	@Description	List an active reservation for resale. The price may not exceed the event's resale price cap, a percentage of the ticket's face value.
+//	@Tags			Resale
+//	@Accept			json
+//	@Produce		json
+//	@Param			eventId	path		string							true	"Event ID"
+//	@Param			listing	body		requests.CreateListingRequest	true	"Listing details"
+//	@Success		201		{object}	models.Listing
+//	@Failure		400		{object}	responses.ValidationErrorResponse
+//	@Failure		403		{object}	responses.ErrorResponse	"Invalid ticket token"
+//	@Failure		404		{object}	responses.ErrorResponse	"Event/ticket/reservation not found"
+//	@Failure		409		{object}	responses.ErrorResponse	"Ticket is already listed / Reservation is not active / Event is not on sale / General admission tickets cannot be resold"
+//	@Failure		500		{object}	responses.ErrorResponse
+//	@Router			/events/{eventId}/listings [post]
+func (r *resaleController) CreateListing(c fiber.Ctx) error {
+	var data requests.CreateListingRequest
+	err := c.Bind().Body(&data)
+	if err != nil {
+		return err
+	}
+
+	eventID := c.Params("eventId")
+
+	resp, err := r.resaleService.CreateListing(c.Context(), eventID, data.TicketID, data.Token, data.Price)
+	if err != nil {
+		if errors.Is(err, services.ErrEventNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Event not found")
+		}
+
+		if errors.Is(err, services.ErrTicketNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Ticket not found")
+		}
+
+		if errors.Is(err, services.ErrEventAlreadyPassed) {
+			return fiber.NewError(fiber.StatusBadRequest, "Event date has already passed")
+		}
+
+		if errors.Is(err, services.ErrEventNotOnSale) {
+			return fiber.NewError(fiber.StatusConflict, "Event is not on sale")
+		}
+
+		if errors.Is(err, services.ErrInvalidTicketToken) {
+			return fiber.NewError(fiber.StatusForbidden, "Invalid ticket token")
+		}
+
+		if errors.Is(err, services.ErrReservationNotActive) {
+			return fiber.NewError(fiber.StatusConflict, "Reservation is not active")
+		}
+
+		if errors.Is(err, services.ErrResalePriceTooHigh) {
+			return fiber.NewError(fiber.StatusBadRequest, "Resale price exceeds the event's price cap")
+		}
+
+		if errors.Is(err, services.ErrTicketAlreadyListed) {
+			return fiber.NewError(fiber.StatusConflict, "Ticket is already listed for resale")
+		}
+
+		if errors.Is(err, services.ErrGeneralAdmissionResale) {
+			return fiber.NewError(fiber.StatusConflict, "General admission tickets cannot be resold")
+		}
+
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(resp)
+}
+
+// GetListingByID godoc
+//
+//	@Summary		Get resale listing by ID
+//	@Description	Get details of a resale listing by its ID
+//	@Tags			Resale
+//	@Accept			json
+//	@Produce		json
+//	@Param			eventId	path		string	true	"Event ID"
+//	@Param			id		path		string	true	"Listing ID"
+//	@Success		200		{object}	models.Listing
+//	@Failure		404		{object}	responses.ErrorResponse	"Listing not found for the given event"
+//	@Failure		500		{object}	responses.ErrorResponse
+//	@Router			/events/{eventId}/listings/{id} [get]
+func (r *resaleController) GetListingByID(c fiber.Ctx) error {
+	eventID := c.Params("eventId")
+	listingID := c.Params("id")
+
+	resp, err := r.resaleService.GetListing(c.Context(), eventID, listingID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(resp)
+}
+
+// GetAllListings godoc
+//
+//	@Summary		Get resale listings for an event
+//	@Description	Retrieve all active resale listings for an event
+//	@Tags			Resale
+//	@Accept			json
+//	@Produce		json
+//	@Param			eventId	path		string	true	"Event ID"
+//	@Success		200		{array}		models.Listing
+//	@Failure		404		{object}	responses.ErrorResponse	"Event not found"
+//	@Failure		500		{object}	responses.ErrorResponse
+//	@Router			/events/{eventId}/listings [get]
+func (r *resaleController) GetAllListings(c fiber.Ctx) error {
+	eventID := c.Params("eventId")
+
+	resp, err := r.resaleService.GetListingsByEvent(c.Context(), eventID)
+	if err != nil {
+		if errors.Is(err, services.ErrEventNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Event not found")
+		}
+
+		return err
+	}
+
+	return c.JSON(resp)
+}
+
+// CancelListing godoc
+//
+//	@Summary		Cancel a resale listing
+//	@Description	Withdraw an active resale listing. Only the seller, proven by their ticket token, can cancel it.
+//	@Tags			Resale
+//	@Accept			json
+//	@Produce		json
+//	@Param			eventId	path	string							true	"Event ID"
+//	@Param			id		path	string							true	"Listing ID"
+//	@Param			listing	body	requests.CancelListingRequest	true	"Seller's ticket token"
+//	@Success		204
+//	@Failure		400	{object}	responses.ValidationErrorResponse
+//	@Failure		403	{object}	responses.ErrorResponse	"Invalid ticket token"
+//	@Failure		404	{object}	responses.ErrorResponse	"Listing not found for the given event"
+//	@Failure		409	{object}	responses.ErrorResponse	"Listing is no longer available"
+//	@Failure		500	{object}	responses.ErrorResponse
+//	@Router			/events/{eventId}/listings/{id} [delete]
+func (r *resaleController) CancelListing(c fiber.Ctx) error {
+	var data requests.CancelListingRequest
+	err := c.Bind().Body(&data)
+	if err != nil {
+		return err
+	}
+
+	eventID := c.Params("eventId")
+	listingID := c.Params("id")
+
+	err = r.resaleService.CancelListing(c.Context(), eventID, listingID, data.Token)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidTicketToken) {
+			return fiber.NewError(fiber.StatusForbidden, "Invalid ticket token")
+		}
+
+		if errors.Is(err, services.ErrListingUnavailable) {
+			return fiber.NewError(fiber.StatusConflict, "Listing is no longer available")
+		}
+
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// PurchaseListing godoc
+//
+//	@Summary		Purchase a resale listing
+//	@Description	Buy a ticket listed for resale. The reservation moves to the buyer with a new ticket token, and a payout record is created for the seller.
+//	@Tags			Resale
+//	@Accept			json
+//	@Produce		json
+//	@Param			eventId		path		string							true	"Event ID"
+//	@Param			id			path		string							true	"Listing ID"
+//	@Param			purchase	body		requests.PurchaseListingRequest	true	"Buyer details"
+//	@Success		201			{object}	models.Reservation
+//	@Failure		400			{object}	responses.ValidationErrorResponse
+//	@Failure		404			{object}	responses.ErrorResponse	"Event/listing not found"
+//	@Failure		409			{object}	responses.ErrorResponse	"Listing is no longer available / Event is not on sale"
+//	@Failure		500			{object}	responses.ErrorResponse
+//	@Router			/events/{eventId}/listings/{id}/purchase [post]
+func (r *resaleController) PurchaseListing(c fiber.Ctx) error {
+	var data requests.PurchaseListingRequest
+	err := c.Bind().Body(&data)
+	if err != nil {
+		return err
+	}
+
+	eventID := c.Params("eventId")
+	listingID := c.Params("id")
+
+	resp, err := r.resaleService.PurchaseListing(c.Context(), eventID, listingID, data.CustomerName)
+	if err != nil {
+		if errors.Is(err, services.ErrEventNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Event not found")
+		}
+
+		if errors.Is(err, services.ErrEventAlreadyPassed) {
+			return fiber.NewError(fiber.StatusBadRequest, "Event date has already passed")
+		}
+
+		if errors.Is(err, services.ErrEventNotOnSale) {
+			return fiber.NewError(fiber.StatusConflict, "Event is not on sale")
+		}
+
+		if errors.Is(err, services.ErrListingUnavailable) {
+			return fiber.NewError(fiber.StatusConflict, "Listing is no longer available")
+		}
+
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(resp)
+}
+
+// GetAllPayouts godoc
+//
+//	@Summary		Get seller payouts for an event
+//	@Description	Retrieve the payout records owed to sellers for completed resales of an event
+//	@Tags			Resale
+//	@Accept			json
+//	@Produce		json
+//	@Param			eventId	path		string	true	"Event ID"
+//	@Success		200		{array}		models.Payout
+//	@Failure		404		{object}	responses.ErrorResponse	"Event not found"
+//	@Failure		500		{object}	responses.ErrorResponse
+//	@Router			/events/{eventId}/payouts [get]
+func (r *resaleController) GetAllPayouts(c fiber.Ctx) error {
+	eventID := c.Params("eventId")
+
+	resp, err := r.resaleService.GetPayoutsByEvent(c.Context(), eventID)
+	if err != nil {
+		if errors.Is(err, services.ErrEventNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Event not found")
+		}
+
+		return err
+	}
+
+	return c.JSON(resp)
+}
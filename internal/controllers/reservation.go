@@ -11,9 +11,11 @@ import (
 
 type ReservationController interface {
 	CreateReservation(c fiber.Ctx) error
+	ReserveBestAvailable(c fiber.Ctx) error
 	GetReservationByID(c fiber.Ctx) error
-	UpdateReservation(c fiber.Ctx) error
 	DeleteReservation(c fiber.Ctx) error
+	StartTransfer(c fiber.Ctx) error
+	AcceptTransfer(c fiber.Ctx) error
 }
 
 type reservationController struct {
@@ -29,18 +31,22 @@ func NewReservationController(reservationService services.ReservationService) Re
 // CreateReservation godoc
 //
 //	@Summary		Create a reservation
-//	@Description	Create a reservation for a ticket
+//	@Description	Create a reservation for a ticket. General admission tickets can be reserved several places at a time with quantity; each reservation gets its own token, which is needed to view, change or cancel it. Reservation attempts are rate limited per client address and per customer name; the RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset headers describe the remaining quota. While the event's virtual queue is enabled, an admitted queue token is required in the X-Queue-Token header.
 //	@Tags			Reservations
 //	@Accept			json
 //	@Produce		json
-//	@Param			eventId		path		string								true	"Event ID"
-//	@Param			ticketId	path		string								true	"Ticket ID"
-//	@Param			reservation	body		requests.CreateReservationRequest	true	"Reservation details"
-//	@Success		201			{object}	models.Reservation
-//	@Failure		400			{object}	responses.ValidationErrorResponse
-//	@Failure		404			{object}	responses.ErrorResponse	"Ticket/event not found"
-//	@Failure		409			{object}	responses.ErrorResponse	"Ticket is already reserved / Event date has already passed"
-//	@Failure		500			{object}	responses.ErrorResponse
+//	@Param			eventId			path		string								true	"Event ID"
+//	@Param			ticketId		path		string								true	"Ticket ID"
+//	@Param			reservation		body		requests.CreateReservationRequest	true	"Reservation details"
+//	@Param			X-Queue-Token	header		string								false	"Admitted queue token, required while the event's queue is enabled"
+//	@Success		201				{object}	models.Reservation
+//	@Failure		400				{object}	responses.ValidationErrorResponse
+//	@Failure		401				{object}	responses.ErrorResponse	"Queue token is required / Invalid queue token"
+//	@Failure		403				{object}	responses.ErrorResponse	"Queue token has not been admitted yet / Queue admission has expired"
+//	@Failure		404				{object}	responses.ErrorResponse	"Ticket/event not found"
+//	@Failure		409				{object}	responses.ErrorResponse	"Ticket is already reserved / Not enough places remaining / Event is not on sale / Reservation would leave a single empty seat"
+//	@Failure		429				{object}	responses.ErrorResponse	"Too many requests, retry after the number of seconds in the Retry-After header"
+//	@Failure		500				{object}	responses.ErrorResponse
 //	@Router			/events/{eventId}/tickets/{ticketId}/reservation [post]
 func (r *reservationController) CreateReservation(c fiber.Ctx) error {
 	var data requests.CreateReservationRequest
@@ -52,24 +58,34 @@ func (r *reservationController) CreateReservation(c fiber.Ctx) error {
 	eventID := c.Params("eventId")
 	ticketID := c.Params("ticketId")
 
-	resp, err := r.reservationService.CreateReservation(c.Context(), eventID, ticketID, data.CustomerName)
+	resp, err := r.reservationService.CreateReservation(c.Context(), eventID, ticketID, data.CustomerName, data.Quantity)
 	if err != nil {
 		if errors.Is(err, services.ErrTicketNotFound) {
-			return c.Status(fiber.StatusNotFound).JSON(responses.ErrorResponse{
-				Message: "Ticket not found",
-			})
+			return fiber.NewError(fiber.StatusNotFound, "Ticket not found")
 		}
 
 		if errors.Is(err, services.ErrTicketAlreadyReserved) {
-			return c.Status(fiber.StatusConflict).JSON(responses.ErrorResponse{
-				Message: "Ticket is already reserved",
-			})
+			return fiber.NewError(fiber.StatusConflict, "Ticket is already reserved")
+		}
+
+		if errors.Is(err, services.ErrNotEnoughPlaces) {
+			return fiber.NewError(fiber.StatusConflict, "Not enough places remaining")
+		}
+
+		if errors.Is(err, services.ErrQuantityNotAllowed) {
+			return fiber.NewError(fiber.StatusBadRequest, "Seated tickets can only be reserved one at a time")
+		}
+
+		if errors.Is(err, services.ErrSingleSeatGap) {
+			return fiber.NewError(fiber.StatusConflict, "Reservation would leave a single empty seat between occupied seats")
+		}
+
+		if errors.Is(err, services.ErrEventNotOnSale) {
+			return fiber.NewError(fiber.StatusConflict, "Event is not on sale")
 		}
 
 		if errors.Is(err, services.ErrEventAlreadyPassed) {
-			return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
-				Message: "Event date has already passed",
-			})
+			return fiber.NewError(fiber.StatusBadRequest, "Event date has already passed")
 		}
 
 		return err
@@ -78,62 +94,95 @@ func (r *reservationController) CreateReservation(c fiber.Ctx) error {
 	return c.Status(fiber.StatusCreated).JSON(resp)
 }
 
-// GetReservationByID godoc
+// ReserveBestAvailable godoc
 //
-//	@Summary		Get reservation
-//	@Description	Get reservation details for a ticket
+//	@Summary		Reserve the best available tickets
+//	@Description	Reserve a number of available tickets chosen by the system, all for the same customer. Consecutive seats in the same row are preferred, and among those the most expensive block within the price ceiling; seats are placed by their section, row and seat. If no such block exists, the closest seats in one row are chosen, then the best tickets overall; adjacent in the response tells which happened. The section limits the search to tickets in that section or category. When the event's seating rules forbid single-seat gaps, blocks that would leave one are skipped. Either every ticket is reserved or none is. Rate limits and the event's virtual queue apply as for single reservations.
 //	@Tags			Reservations
 //	@Accept			json
 //	@Produce		json
-//	@Param			eventId		path		string	true	"Event ID"
-//	@Param			ticketId	path		string	true	"Ticket ID"
-//	@Success		200			{object}	models.Reservation
-//	@Failure		404			{object}	responses.ErrorResponse
-//	@Failure		500			{object}	responses.ErrorResponse
-//	@Router			/events/{eventId}/tickets/{ticketId}/reservation [get]
-func (r *reservationController) GetReservationByID(c fiber.Ctx) error {
+//	@Param			eventId			path		string							true	"Event ID"
+//	@Param			reservation		body		requests.BestAvailableRequest	true	"Reservation details"
+//	@Param			X-Queue-Token	header		string							false	"Admitted queue token, required while the event's queue is enabled"
+//	@Success		201				{object}	models.BestAvailableReservation
+//	@Failure		400				{object}	responses.ValidationErrorResponse
+//	@Failure		401				{object}	responses.ErrorResponse	"Queue token is required / Invalid queue token"
+//	@Failure		403				{object}	responses.ErrorResponse	"Queue token has not been admitted yet / Queue admission has expired"
+//	@Failure		404				{object}	responses.ErrorResponse	"Event not found"
+//	@Failure		409				{object}	responses.ErrorResponse	"Not enough available tickets / Event is not on sale / Reservation would leave a single empty seat"
+//	@Failure		429				{object}	responses.ErrorResponse	"Too many requests, retry after the number of seconds in the Retry-After header"
+//	@Failure		500				{object}	responses.ErrorResponse
+//	@Router			/events/{eventId}/reservations/best-available [post]
+func (r *reservationController) ReserveBestAvailable(c fiber.Ctx) error {
+	var data requests.BestAvailableRequest
+	err := c.Bind().Body(&data)
+	if err != nil {
+		return err
+	}
+
 	eventID := c.Params("eventId")
-	ticketID := c.Params("ticketId")
 
-	resp, err := r.reservationService.GetReservation(c.Context(), eventID, ticketID)
+	resp, err := r.reservationService.ReserveBestAvailable(c.Context(), eventID, data.CustomerName, data.Quantity, data.MaxPrice, data.Section)
 	if err != nil {
+		if errors.Is(err, services.ErrEventNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Event not found")
+		}
+
+		if errors.Is(err, services.ErrNotEnoughTickets) {
+			return fiber.NewError(fiber.StatusConflict, "Not enough available tickets match the request")
+		}
+
+		if errors.Is(err, services.ErrTicketAlreadyReserved) {
+			return fiber.NewError(fiber.StatusConflict, "Tickets were reserved by someone else, please try again")
+		}
+
+		if errors.Is(err, services.ErrSingleSeatGap) {
+			return fiber.NewError(fiber.StatusConflict, "Reservation would leave a single empty seat between occupied seats")
+		}
+
+		if errors.Is(err, services.ErrEventNotOnSale) {
+			return fiber.NewError(fiber.StatusConflict, "Event is not on sale")
+		}
+
+		if errors.Is(err, services.ErrEventAlreadyPassed) {
+			return fiber.NewError(fiber.StatusBadRequest, "Event date has already passed")
+		}
+
 		return err
 	}
 
-	return c.JSON(resp)
+	return c.Status(fiber.StatusCreated).JSON(resp)
 }
 
-// UpdateReservation godoc
+// GetReservationByID godoc
 //
-//	@Summary		Update a reservation
-//	@Description	Update details of an existing reservation
+//	@Summary		Get reservation
+//	@Description	Get reservation details for a ticket. Only the holder can read a reservation, by presenting its token; general admission tickets have a reservation per buyer, and the token selects it.
 //	@Tags			Reservations
 //	@Accept			json
 //	@Produce		json
-//	@Param			eventId		path		string								true	"Event ID"
-//	@Param			ticketId	path		string								true	"Ticket ID"
-//	@Param			reservation	body		requests.UpdateReservationRequest	true	"Updated reservation details"
+//	@Param			eventId		path		string	true	"Event ID"
+//	@Param			ticketId	path		string	true	"Ticket ID"
+//	@Param			token		query		string	true	"Reservation token"
 //	@Success		200			{object}	models.Reservation
-//	@Failure		400			{object}	responses.ValidationErrorResponse
+//	@Failure		400			{object}	responses.ErrorResponse	"Reservation token is required"
 //	@Failure		404			{object}	responses.ErrorResponse
 //	@Failure		500			{object}	responses.ErrorResponse
-//	@Router			/events/{eventId}/tickets/{ticketId}/reservation [patch]
-func (r *reservationController) UpdateReservation(c fiber.Ctx) error {
+//	@Router			/events/{eventId}/tickets/{ticketId}/reservation [get]
+func (r *reservationController) GetReservationByID(c fiber.Ctx) error {
 	eventID := c.Params("eventId")
 	ticketID := c.Params("ticketId")
 
-	var data requests.UpdateReservationRequest
-	err := c.Bind().Body(&data)
+	var query requests.ReservationTokenQuery
+	err := c.Bind().Query(&query)
 	if err != nil {
 		return err
 	}
 
-	resp, err := r.reservationService.UpdateReservation(c.Context(), eventID, ticketID, data.CustomerName)
+	resp, err := r.reservationService.GetReservation(c.Context(), eventID, ticketID, query.Token)
 	if err != nil {
-		if errors.Is(err, services.ErrEventAlreadyPassed) {
-			return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
-				Message: "Event date has already passed",
-			})
+		if errors.Is(err, services.ErrTokenRequired) {
+			return fiber.NewError(fiber.StatusBadRequest, "Reservation token is required")
 		}
 
 		return err
@@ -145,26 +194,41 @@ func (r *reservationController) UpdateReservation(c fiber.Ctx) error {
 // DeleteReservation godoc
 //
 //	@Summary		Cancel a reservation
-//	@Description	Cancel and delete an existing reservation
+//	@Description	Cancel an existing reservation. The ticket becomes available again and the cancelled reservation is kept for reporting. The holder proves ownership with the reservation token. Cancelling a general admission reservation returns its places to the ticket's capacity.
 //	@Tags			Reservations
 //	@Accept			json
 //	@Produce		json
 //	@Param			eventId		path	string	true	"Event ID"
 //	@Param			ticketId	path	string	true	"Ticket ID"
+//	@Param			token		query	string	true	"Reservation token"
 //	@Success		204
+//	@Failure		400	{object}	responses.ErrorResponse	"Reservation token is required"
 //	@Failure		404	{object}	responses.ErrorResponse
+//	@Failure		409	{object}	responses.ErrorResponse	"Reservation has already been checked in"
 //	@Failure		500	{object}	responses.ErrorResponse
 //	@Router			/events/{eventId}/tickets/{ticketId}/reservation [delete]
 func (r *reservationController) DeleteReservation(c fiber.Ctx) error {
 	eventID := c.Params("eventId")
 	ticketID := c.Params("ticketId")
 
-	err := r.reservationService.CancelReservation(c.Context(), eventID, ticketID)
+	var query requests.ReservationTokenQuery
+	err := c.Bind().Query(&query)
+	if err != nil {
+		return err
+	}
+
+	err = r.reservationService.CancelReservation(c.Context(), eventID, ticketID, query.Token)
 	if err != nil {
+		if errors.Is(err, services.ErrTokenRequired) {
+			return fiber.NewError(fiber.StatusBadRequest, "Reservation token is required")
+		}
+
 		if errors.Is(err, services.ErrEventAlreadyPassed) {
-			return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
-				Message: "Event date has already passed",
-			})
+			return fiber.NewError(fiber.StatusBadRequest, "Event date has already passed")
+		}
+
+		if errors.Is(err, services.ErrReservationCheckedIn) {
+			return fiber.NewError(fiber.StatusConflict, "Reservation has already been checked in")
 		}
 
 		return err
@@ -172,3 +236,100 @@ func (r *reservationController) DeleteReservation(c fiber.Ctx) error {
 
 	return c.SendStatus(fiber.StatusNoContent)
 }
+
+// StartTransfer godoc
+//
+//	@Summary		Start a ticket transfer
+//	@Description	Start transferring a reservation to another customer. The holder proves ownership with their ticket token and receives a one-time code to hand over to the recipient. Starting a new transfer replaces any pending one.
+//	@Tags			Reservations
+//	@Accept			json
+//	@Produce		json
+//	@Param			eventId		path		string							true	"Event ID"
+//	@Param			ticketId	path		string							true	"Ticket ID"
+//	@Param			transfer	body		requests.CreateTransferRequest	true	"Transfer details"
+//	@Success		201			{object}	responses.TransferResponse
+//	@Failure		400			{object}	responses.ValidationErrorResponse
+//	@Failure		403			{object}	responses.ErrorResponse	"Invalid ticket token"
+//	@Failure		404			{object}	responses.ErrorResponse
+//	@Failure		409			{object}	responses.ErrorResponse	"Reservation is not active / Ticket is listed for resale"
+//	@Failure		500			{object}	responses.ErrorResponse
+//	@Router			/events/{eventId}/tickets/{ticketId}/reservation/transfer [post]
+func (r *reservationController) StartTransfer(c fiber.Ctx) error {
+	var data requests.CreateTransferRequest
+	err := c.Bind().Body(&data)
+	if err != nil {
+		return err
+	}
+
+	eventID := c.Params("eventId")
+	ticketID := c.Params("ticketId")
+
+	resp, err := r.reservationService.StartTransfer(c.Context(), eventID, ticketID, data.Token, data.RecipientName)
+	if err != nil {
+		if errors.Is(err, services.ErrEventAlreadyPassed) {
+			return fiber.NewError(fiber.StatusBadRequest, "Event date has already passed")
+		}
+
+		if errors.Is(err, services.ErrInvalidTicketToken) {
+			return fiber.NewError(fiber.StatusForbidden, "Invalid ticket token")
+		}
+
+		if errors.Is(err, services.ErrReservationNotActive) {
+			return fiber.NewError(fiber.StatusConflict, "Reservation is not active")
+		}
+
+		if errors.Is(err, services.ErrTicketAlreadyListed) {
+			return fiber.NewError(fiber.StatusConflict, "Ticket is listed for resale")
+		}
+
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(responses.TransferResponse{
+		RecipientName: resp.RecipientName,
+		Code:          resp.Code,
+		ExpiresAt:     resp.ExpiresAt,
+	})
+}
+
+// AcceptTransfer godoc
+//
+//	@Summary		Accept a ticket transfer
+//	@Description	Accept a pending transfer with its one-time code. The reservation moves to the recipient and a new ticket token is issued, invalidating the previous one.
+//	@Tags			Reservations
+//	@Accept			json
+//	@Produce		json
+//	@Param			eventId		path		string							true	"Event ID"
+//	@Param			ticketId	path		string							true	"Ticket ID"
+//	@Param			transfer	body		requests.AcceptTransferRequest	true	"Transfer code"
+//	@Success		200			{object}	models.Reservation
+//	@Failure		400			{object}	responses.ValidationErrorResponse
+//	@Failure		403			{object}	responses.ErrorResponse	"Invalid or expired transfer code"
+//	@Failure		404			{object}	responses.ErrorResponse
+//	@Failure		500			{object}	responses.ErrorResponse
+//	@Router			/events/{eventId}/tickets/{ticketId}/reservation/transfer/accept [post]
+func (r *reservationController) AcceptTransfer(c fiber.Ctx) error {
+	var data requests.AcceptTransferRequest
+	err := c.Bind().Body(&data)
+	if err != nil {
+		return err
+	}
+
+	eventID := c.Params("eventId")
+	ticketID := c.Params("ticketId")
+
+	resp, err := r.reservationService.AcceptTransfer(c.Context(), eventID, ticketID, data.Code)
+	if err != nil {
+		if errors.Is(err, services.ErrEventAlreadyPassed) {
+			return fiber.NewError(fiber.StatusBadRequest, "Event date has already passed")
+		}
+
+		if errors.Is(err, services.ErrInvalidTransferCode) {
+			return fiber.NewError(fiber.StatusForbidden, "Invalid or expired transfer code")
+		}
+
+		return err
+	}
+
+	return c.JSON(resp)
+}
@@ -0,0 +1,188 @@
+package controllers
+
+import (
+	"errors"
+	"time"
+
+	"github.com/enxg/skyticket/internal/models"
+	"github.com/enxg/skyticket/internal/requests"
+	"github.com/enxg/skyticket/internal/services"
+	"github.com/gofiber/fiber/v3"
+)
+
+type SeriesController interface {
+	CreateSeries(c fiber.Ctx) error
+	GetSeriesByID(c fiber.Ctx) error
+	GetSeriesEvents(c fiber.Ctx) error
+	UpdateSeries(c fiber.Ctx) error
+}
+
+type seriesController struct {
+	seriesService services.SeriesService
+}
+
+func NewSeriesController(seriesService services.SeriesService) SeriesController {
+	return &seriesController{
+		seriesService: seriesService,
+	}
+}
+
+// CreateSeries godoc
+//
+//	@Summary		Create an event series
+//	@Description	Create a recurring event series. One draft event is created for every occurrence of the recurrence rule, each with its own copy of the ticket inventory and the series' category, tags and seating rules. Supported RRULE parts are FREQ (DAILY, WEEKLY, MONTHLY), INTERVAL, COUNT, UNTIL and BYDAY; either COUNT or UNTIL is required and at most 366 events can be created. Occurrences keep the same local time in the series' timezone across DST changes.
+//	@Tags			Series
+//	@Accept			json
+//	@Produce		json
+//	@Param			series	body		requests.CreateSeriesRequest	true	"Series details"
+//	@Success		201		{object}	models.EventSeries
+//	@Failure		400		{object}	responses.ValidationErrorResponse
+//	@Failure		409		{object}	responses.ErrorResponse	"Seat number is already taken"
+//	@Failure		500		{object}	responses.ErrorResponse
+//	@Router			/series [post]
+func (s *seriesController) CreateSeries(c fiber.Ctx) error {
+	var data requests.CreateSeriesRequest
+	err := c.Bind().Body(&data)
+	if err != nil {
+		return err
+	}
+
+	start, err := requests.ParseDate(data.Start, data.Timezone)
+	if err != nil {
+		if errors.Is(err, requests.ErrTimezoneRequired) {
+			return fiber.NewError(fiber.StatusBadRequest, "Timezone is required when the date has no UTC offset")
+		}
+
+		return err
+	}
+
+	if time.Now().After(start) {
+		return fiber.NewError(fiber.StatusBadRequest, "Series start date cannot be in the past")
+	}
+
+	tickets := make([]models.SeriesTicket, len(data.Tickets))
+	for i, ticket := range data.Tickets {
+		tickets[i] = models.SeriesTicket{
+			SeatNumber: ticket.SeatNumber,
+			Price:      ticket.Price,
+			Category:   ticket.Category,
+			Section:    ticket.Section,
+			Row:        ticket.Row,
+			Seat:       ticket.Seat,
+			Capacity:   ticket.Capacity,
+		}
+	}
+
+	resp, err := s.seriesService.CreateSeries(c.Context(), models.EventSeries{
+		Name:           data.Name,
+		Venue:          data.Venue,
+		Start:          start,
+		Timezone:       data.Timezone,
+		Recurrence:     data.Recurrence,
+		ResalePriceCap: data.ResalePriceCap,
+		Tickets:        tickets,
+		Address:        toAddress(data.Address),
+		Location:       toGeoPoint(data.Location),
+		Category:       data.Category,
+		Tags:           data.Tags,
+		SeatingRules:   toSeatingRules(data.SeatingRules),
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidRecurrence) {
+			return fiber.NewError(fiber.StatusBadRequest, "Invalid recurrence rule")
+		}
+
+		if errors.Is(err, services.ErrTooManyOccurrences) {
+			return fiber.NewError(fiber.StatusBadRequest, "Recurrence rule produces too many events")
+		}
+
+		if errors.Is(err, services.ErrSeatNumberTaken) {
+			return fiber.NewError(fiber.StatusConflict, "Seat number is already taken")
+		}
+
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(resp)
+}
+
+// GetSeriesByID godoc
+//
+//	@Summary		Get event series by ID
+//	@Description	Get details of an event series by its ID
+//	@Tags			Series
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Series ID"
+//	@Success		200	{object}	models.EventSeries
+//	@Failure		404	{object}	responses.ErrorResponse
+//	@Failure		500	{object}	responses.ErrorResponse
+//	@Router			/series/{id} [get]
+func (s *seriesController) GetSeriesByID(c fiber.Ctx) error {
+	id := c.Params("id")
+
+	resp, err := s.seriesService.GetSeriesByID(c.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(resp)
+}
+
+// GetSeriesEvents godoc
+//
+//	@Summary		Get events of a series
+//	@Description	Retrieve all event instances that belong to an event series
+//	@Tags			Series
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		string	true	"Series ID"
+//	@Success		200	{array}		models.Event
+//	@Failure		404	{object}	responses.ErrorResponse
+//	@Failure		500	{object}	responses.ErrorResponse
+//	@Router			/series/{id}/events [get]
+func (s *seriesController) GetSeriesEvents(c fiber.Ctx) error {
+	id := c.Params("id")
+
+	resp, err := s.seriesService.GetSeriesEvents(c.Context(), id)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(resp)
+}
+
+// UpdateSeries godoc
+//
+//	@Summary		Update an event series
+//	@Description	Update the details of an event series. Changes are applied to the series and to its future event instances only; past, cancelled and completed instances are left untouched. At least one field must be set.
+//	@Tags			Series
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string							true	"Series ID"
+//	@Param			series	body		requests.UpdateSeriesRequest	true	"Updated series details"
+//	@Success		200		{object}	models.EventSeries
+//	@Failure		400		{object}	responses.ValidationErrorResponse
+//	@Failure		404		{object}	responses.ErrorResponse
+//	@Failure		500		{object}	responses.ErrorResponse
+//	@Router			/series/{id} [patch]
+func (s *seriesController) UpdateSeries(c fiber.Ctx) error {
+	id := c.Params("id")
+
+	var data requests.UpdateSeriesRequest
+	err := c.Bind().Body(&data)
+	if err != nil {
+		return err
+	}
+
+	resp, err := s.seriesService.UpdateSeries(c.Context(), id, data.Name, data.Venue, data.ResalePriceCap, toAddress(data.Address), toGeoPoint(data.Location))
+	if err != nil {
+		if errors.Is(err, services.ErrNoSeriesChanges) {
+			return fiber.NewError(fiber.StatusBadRequest, "At least one field must be set")
+		}
+
+		return err
+	}
+
+	return c.JSON(resp)
+}
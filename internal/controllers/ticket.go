@@ -1,11 +1,19 @@
 package controllers
 
 import (
+	"bytes"
+	"encoding/csv"
 	"errors"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
 
+	"github.com/enxg/skyticket/internal/imports"
 	"github.com/enxg/skyticket/internal/requests"
 	"github.com/enxg/skyticket/internal/responses"
 	"github.com/enxg/skyticket/internal/services"
+	"github.com/enxg/skyticket/pkg/validator"
 	"github.com/gofiber/fiber/v3"
 )
 
@@ -15,22 +23,25 @@ type TicketController interface {
 	GetAllTickets(c fiber.Ctx) error
 	UpdateTicket(c fiber.Ctx) error
 	DeleteTicket(c fiber.Ctx) error
+	ImportTickets(c fiber.Ctx) error
 }
 
 type ticketController struct {
 	ticketService services.TicketService
+	validator     validator.StructValidator
 }
 
-func NewTicketController(ticketService services.TicketService) TicketController {
+func NewTicketController(ticketService services.TicketService, structValidator validator.StructValidator) TicketController {
 	return &ticketController{
 		ticketService: ticketService,
+		validator:     structValidator,
 	}
 }
 
 // CreateTicket godoc
 //
 //	@Summary		Create a ticket
-//	@Description	Create a ticket with the provided details
+//	@Description	Create a ticket with the provided details. A ticket with a capacity is a general admission ticket: it has no seat and can be reserved until its places run out.
 //	@Tags			Tickets
 //	@Accept			json
 //	@Produce		json
@@ -39,7 +50,7 @@ func NewTicketController(ticketService services.TicketService) TicketController
 //	@Success		201		{object}	models.Ticket
 //	@Failure		400		{object}	responses.ValidationErrorResponse
 //	@Failure		404		{object}	responses.ErrorResponse	"Event not found"
-//	@Failure		409		{object}	responses.ErrorResponse	"Seat number is already taken"
+//	@Failure		409		{object}	responses.ErrorResponse	"Seat number is already taken / Event has been cancelled"
 //	@Failure		500		{object}	responses.ErrorResponse
 //	@Router			/events/{eventId}/tickets [post]
 func (t *ticketController) CreateTicket(c fiber.Ctx) error {
@@ -51,24 +62,22 @@ func (t *ticketController) CreateTicket(c fiber.Ctx) error {
 
 	eventId := c.Params("eventId")
 
-	resp, err := t.ticketService.CreateTicket(c.Context(), eventId, data.SeatNumber, data.Price)
+	resp, err := t.ticketService.CreateTicket(c.Context(), eventId, data.SeatNumber, data.Price, data.Category, data.Section, data.Row, data.Seat, data.Capacity)
 	if err != nil {
 		if errors.Is(err, services.ErrEventNotFound) {
-			return c.Status(fiber.StatusNotFound).JSON(responses.ErrorResponse{
-				Message: "Event not found",
-			})
+			return fiber.NewError(fiber.StatusNotFound, "Event not found")
 		}
 
 		if errors.Is(err, services.ErrSeatNumberTaken) {
-			return c.Status(fiber.StatusConflict).JSON(responses.ErrorResponse{
-				Message: "Seat number is already taken",
-			})
+			return fiber.NewError(fiber.StatusConflict, "Seat number is already taken")
 		}
 
 		if errors.Is(err, services.ErrEventAlreadyPassed) {
-			return c.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
-				Message: "Event date has already passed",
-			})
+			return fiber.NewError(fiber.StatusBadRequest, "Event date has already passed")
+		}
+
+		if errors.Is(err, services.ErrEventCancelled) {
+			return fiber.NewError(fiber.StatusConflict, "Event has been cancelled")
 		}
 
 		return err
@@ -120,9 +129,7 @@ func (t *ticketController) GetAllTickets(c fiber.Ctx) error {
 	resp, err := t.ticketService.GetTicketsByEvent(c.Context(), eventId)
 	if err != nil {
 		if errors.Is(err, services.ErrEventNotFound) {
-			return c.Status(fiber.StatusNotFound).JSON(responses.ErrorResponse{
-				Message: "Event not found",
-			})
+			return fiber.NewError(fiber.StatusNotFound, "Event not found")
 		}
 
 		return err
@@ -134,7 +141,7 @@ func (t *ticketController) GetAllTickets(c fiber.Ctx) error {
 // UpdateTicket godoc
 //
 //	@Summary		Update an existing ticket
-//	@Description	Update the details of an existing ticket by its ID
+//	@Description	Update the details of an existing ticket by its ID. The capacity of a general admission ticket cannot drop below the places already sold, and seated tickets have no capacity.
 //	@Tags			Tickets
 //	@Accept			json
 //	@Produce		json
@@ -144,6 +151,7 @@ func (t *ticketController) GetAllTickets(c fiber.Ctx) error {
 //	@Success		200		{object}	models.Ticket
 //	@Failure		400		{object}	responses.ValidationErrorResponse
 //	@Failure		404		{object}	responses.ErrorResponse	"Ticket not found for the given event"
+//	@Failure		409		{object}	responses.ErrorResponse	"Seat number is already taken / Capacity is below the places already sold"
 //	@Failure		500		{object}	responses.ErrorResponse
 //	@Router			/events/{eventId}/tickets/{id} [patch]
 func (t *ticketController) UpdateTicket(c fiber.Ctx) error {
@@ -156,12 +164,18 @@ func (t *ticketController) UpdateTicket(c fiber.Ctx) error {
 		return err
 	}
 
-	resp, err := t.ticketService.UpdateTicket(c.Context(), ticketId, eventId, data.SeatNumber, data.Price)
+	resp, err := t.ticketService.UpdateTicket(c.Context(), ticketId, eventId, data.SeatNumber, data.Price, data.Category, data.Section, data.Row, data.Seat, data.Capacity)
 	if err != nil {
 		if errors.Is(err, services.ErrSeatNumberTaken) {
-			return c.Status(fiber.StatusConflict).JSON(responses.ErrorResponse{
-				Message: "Seat number is already taken",
-			})
+			return fiber.NewError(fiber.StatusConflict, "Seat number is already taken")
+		}
+
+		if errors.Is(err, services.ErrCapacityBelowSold) {
+			return fiber.NewError(fiber.StatusConflict, "Capacity is below the number of places already sold")
+		}
+
+		if errors.Is(err, services.ErrTicketTypeMismatch) {
+			return fiber.NewError(fiber.StatusBadRequest, "Seats only apply to seated tickets and capacity only to general admission tickets")
 		}
 
 		return err
@@ -194,3 +208,129 @@ func (t *ticketController) DeleteTicket(c fiber.Ctx) error {
 
 	return c.SendStatus(fiber.StatusNoContent)
 }
+
+// ImportTickets godoc
+//
+//	@Summary		Import tickets from CSV
+//	@Description	Create many tickets at once from CSV rows of seat_number,price[,category[,section[,row,seat]]]. Row and seat default to the letters and number of the seat number, such as A and 12 for A12. A header row is optional. The CSV is sent either as the request body or as the "file" field of a multipart form. Every row is validated like a single ticket; if any row is invalid nothing is created and the per-row errors are returned. With dry_run=true the rows are only checked.
+//	@Tags			Tickets
+//	@Accept			text/csv
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			eventId	path		string							true	"Event ID"
+//	@Param			dry_run	query		bool							false	"Only validate the rows without creating tickets"
+//	@Param			file	formData	file							false	"CSV file"
+//	@Success		200		{object}	responses.TicketImportResponse	"Dry run report"
+//	@Success		201		{object}	responses.TicketImportResponse
+//	@Failure		400		{object}	responses.TicketImportResponse	"Some rows are invalid"
+//	@Failure		404		{object}	responses.ErrorResponse			"Event not found"
+//	@Failure		409		{object}	responses.ErrorResponse			"Event has been cancelled or a seat was taken concurrently"
+//	@Failure		500		{object}	responses.ErrorResponse
+//	@Router			/events/{eventId}/tickets/import [post]
+func (t *ticketController) ImportTickets(c fiber.Ctx) error {
+	eventId := c.Params("eventId")
+
+	var query requests.ImportTicketsRequest
+	err := c.Bind().Query(&query)
+	if err != nil {
+		return err
+	}
+
+	var body io.Reader = bytes.NewReader(c.Body())
+	if strings.HasPrefix(c.Get(fiber.HeaderContentType), fiber.MIMEMultipartForm) {
+		header, err := c.FormFile("file")
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "A CSV file is required in the \"file\" field")
+		}
+
+		file, err := header.Open()
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		body = file
+	}
+
+	rows, err := imports.ReadTickets(body, t.validator)
+	if err != nil {
+		if errors.Is(err, imports.ErrTooManyTicketRows) {
+			return fiber.NewError(fiber.StatusBadRequest, "Ticket imports are limited to "+strconv.Itoa(imports.MaxTicketRows)+" rows")
+		}
+
+		var pe *csv.ParseError
+		if errors.As(err, &pe) {
+			return fiber.NewError(fiber.StatusBadRequest, "Malformed CSV on line "+strconv.Itoa(pe.Line)+".")
+		}
+
+		return err
+	}
+
+	resp := responses.TicketImportResponse{
+		DryRun: query.DryRun,
+		Rows:   len(rows),
+		Errors: make([]responses.TicketImportRowError, 0),
+	}
+
+	for _, row := range rows {
+		if len(row.Errors) > 0 {
+			resp.Errors = append(resp.Errors, responses.TicketImportRowError{
+				Line:   row.Line,
+				Errors: row.Errors,
+			})
+		}
+	}
+
+	tickets, indexes := imports.ValidTickets(rows)
+
+	created, seatErrs, err := t.ticketService.ImportTickets(c.Context(), eventId, tickets, query.DryRun || len(resp.Errors) > 0)
+	if err != nil {
+		if errors.Is(err, services.ErrEventNotFound) {
+			return fiber.NewError(fiber.StatusNotFound, "Event not found")
+		}
+
+		if errors.Is(err, services.ErrEventAlreadyPassed) {
+			return fiber.NewError(fiber.StatusBadRequest, "Event date has already passed")
+		}
+
+		if errors.Is(err, services.ErrEventCancelled) {
+			return fiber.NewError(fiber.StatusConflict, "Event has been cancelled")
+		}
+
+		if errors.Is(err, services.ErrSeatNumberTaken) {
+			return fiber.NewError(fiber.StatusConflict, "Seat number is already taken")
+		}
+
+		return err
+	}
+
+	resp.Valid = len(tickets)
+	for i, err := range seatErrs {
+		if errors.Is(err, services.ErrSeatNumberTaken) {
+			resp.Valid--
+			resp.Errors = append(resp.Errors, responses.TicketImportRowError{
+				Line: rows[indexes[i]].Line,
+				Errors: []validator.ValidationError{
+					{Field: "seat_number", Error: "seat_number is already taken."},
+				},
+			})
+		}
+	}
+
+	slices.SortFunc(resp.Errors, func(a, b responses.TicketImportRowError) int {
+		return a.Line - b.Line
+	})
+
+	if query.DryRun {
+		return c.JSON(resp)
+	}
+
+	if len(resp.Errors) > 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(resp)
+	}
+
+	resp.Created = len(created)
+	resp.Tickets = created
+
+	return c.Status(fiber.StatusCreated).JSON(resp)
+}
@@ -0,0 +1,139 @@
+package imports
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/enxg/skyticket/internal/models"
+	"github.com/enxg/skyticket/internal/requests"
+	"github.com/enxg/skyticket/pkg/validator"
+)
+
+const MaxTicketRows = 10000
+
+var ErrTooManyTicketRows = fmt.Errorf("ticket imports are limited to %d rows", MaxTicketRows)
+
+type TicketRow struct {
+	Line   int
+	Ticket models.Ticket
+	Errors []validator.ValidationError
+}
+
+// ReadTickets reads seat_number,price[,category[,section[,row,seat]]] rows from CSV, skipping an
+// optional header row. Every row is validated with the same rules as
+// requests.CreateTicketRequest; rows that fail carry their errors instead of
+// a ticket.
+func ReadTickets(r io.Reader, structValidator validator.StructValidator) ([]TicketRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var rows []TicketRow
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, _ := reader.FieldPos(0)
+		if len(rows) == 0 && line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "seat_number") {
+			continue
+		}
+
+		if len(rows) == MaxTicketRows {
+			return nil, ErrTooManyTicketRows
+		}
+
+		row, err := parseTicketRow(record, structValidator)
+		if err != nil {
+			return nil, err
+		}
+
+		row.Line = line
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// ValidTickets returns the tickets of the rows that passed validation, along
+// with the index of the row each ticket came from.
+func ValidTickets(rows []TicketRow) ([]models.Ticket, []int) {
+	tickets := make([]models.Ticket, 0, len(rows))
+	indexes := make([]int, 0, len(rows))
+	for i, row := range rows {
+		if len(row.Errors) == 0 {
+			tickets = append(tickets, row.Ticket)
+			indexes = append(indexes, i)
+		}
+	}
+
+	return tickets, indexes
+}
+
+func parseTicketRow(record []string, structValidator validator.StructValidator) (TicketRow, error) {
+	for i := range record {
+		record[i] = strings.TrimSpace(record[i])
+	}
+
+	if len(record) < 2 || len(record) == 5 || len(record) > 6 {
+		return TicketRow{Errors: []validator.ValidationError{
+			{Field: "row", Error: "row must have 2, 3, 4 or 6 columns: seat_number,price[,category[,section[,row,seat]]]."},
+		}}, nil
+	}
+
+	price, err := strconv.Atoi(record[1])
+	if err != nil {
+		return TicketRow{Errors: []validator.ValidationError{
+			{Field: "price", Error: "price must be a whole number in minor units."},
+		}}, nil
+	}
+
+	data := requests.CreateTicketRequest{
+		SeatNumber: record[0],
+		Price:      price,
+	}
+	if len(record) > 2 {
+		data.Category = record[2]
+	}
+	if len(record) > 3 {
+		data.Section = record[3]
+	}
+	if len(record) > 5 {
+		data.Row = record[4]
+
+		if record[5] != "" {
+			data.Seat, err = strconv.Atoi(record[5])
+			if err != nil {
+				return TicketRow{Errors: []validator.ValidationError{
+					{Field: "seat", Error: "seat must be a whole number."},
+				}}, nil
+			}
+		}
+	}
+
+	err = structValidator.Validate(data)
+	if err != nil {
+		var validationErrors validator.ValidationErrors
+		if errors.As(err, &validationErrors) {
+			return TicketRow{Errors: validator.ParseValidationErrors(validationErrors)}, nil
+		}
+		return TicketRow{}, err
+	}
+
+	return TicketRow{Ticket: models.Ticket{
+		SeatNumber: data.SeatNumber,
+		Price:      data.Price,
+		Category:   data.Category,
+		Section:    data.Section,
+		Row:        data.Row,
+		Seat:       data.Seat,
+	}}, nil
+}
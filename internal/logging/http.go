@@ -0,0 +1,77 @@
+package logging
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+const maxRequestIDLength = 128
+
+// Middleware assigns every request an ID, taken from the X-Request-ID header
+// when the client sends a usable one, and writes an access log entry once the
+// response is ready. The ID is echoed in the response header; the error
+// handler also adds it to error bodies.
+//
+// Only the method, route and path are logged; request bodies and query
+// strings may contain customer data and are left out.
+func Middleware() fiber.Handler {
+	return func(c fiber.Ctx) error {
+		start := time.Now()
+
+		requestID := c.Get(RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = uuid.NewString()
+		}
+
+		c.Set(RequestIDHeader, requestID)
+		c.SetContext(WithRequestID(c.Context(), requestID))
+
+		err := c.Next()
+		if err != nil {
+			// Run the error handler now so the final status code is logged.
+			if err := c.App().ErrorHandler(c, err); err != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+
+		status := c.Response().StatusCode()
+
+		var event *zerolog.Event
+		logger := FromContext(c.Context())
+		switch {
+		case status >= fiber.StatusInternalServerError:
+			event = logger.Error()
+		case status >= fiber.StatusBadRequest:
+			event = logger.Warn()
+		default:
+			event = logger.Info()
+		}
+
+		event.
+			Str("method", c.Method()).
+			Str("route", c.Route().Path).
+			Str("path", c.Path()).
+			Int("status", status).
+			Dur("latency", time.Since(start)).
+			Str("ip", c.IP()).
+			Msg("request")
+
+		return nil
+	}
+}
+
+func validRequestID(requestID string) bool {
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		return false
+	}
+
+	return !strings.ContainsFunc(requestID, func(r rune) bool {
+		return r < '!' || r > '~'
+	})
+}
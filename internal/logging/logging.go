@@ -0,0 +1,49 @@
+package logging
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+type requestIDKey struct{}
+
+// WithRequestID returns a context carrying the request ID and a logger that
+// adds it to every entry.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	ctx = context.WithValue(ctx, requestIDKey{}, requestID)
+
+	logger := log.With().Str("request_id", requestID).Logger()
+	return logger.WithContext(ctx)
+}
+
+// RequestID returns the ID of the request the context belongs to, or an empty
+// string outside of requests.
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
+
+// FromContext returns the request logger stored in the context, falling back
+// to the global logger.
+func FromContext(ctx context.Context) *zerolog.Logger {
+	logger := zerolog.Ctx(ctx)
+	if logger.GetLevel() == zerolog.Disabled {
+		return &log.Logger
+	}
+
+	return logger
+}
+
+// RedactName masks a customer name for logging, keeping only the first letter
+// of each word: "Enes Genç" becomes "E*** G***".
+func RedactName(name string) string {
+	words := strings.Fields(name)
+	for i, word := range words {
+		words[i] = string([]rune(word)[0]) + "***"
+	}
+
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,98 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "skyticket"
+
+var (
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Duration of HTTP requests by method, route pattern and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	queryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "mongo",
+		Name:      "operation_duration_seconds",
+		Help:      "Duration of MongoDB operations by repository and method.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"repository", "method"})
+
+	transactions = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "mongo",
+		Name:      "transactions_total",
+		Help:      "Number of MongoDB transactions started by operation.",
+	}, []string{"operation"})
+
+	transactionRetries = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "mongo",
+		Name:      "transaction_retries_total",
+		Help:      "Number of times a MongoDB transaction was retried after a transient error, by operation.",
+	}, []string{"operation"})
+)
+
+var (
+	ReservationsCreated = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reservations_created_total",
+		Help:      "Number of reservations created.",
+	})
+
+	ReservationConflicts = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reservation_conflicts_total",
+		Help:      "Number of reservation attempts for tickets that were already reserved.",
+	})
+
+	ReservationsCancelled = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "reservations_cancelled_total",
+		Help:      "Number of reservations cancelled.",
+	})
+
+	// Revenue is in minor currency units, like ticket prices.
+	Revenue = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "revenue_total",
+		Help:      "Ticket revenue in minor currency units, by source (primary or resale).",
+	}, []string{"source"})
+)
+
+// ObserveQuery starts timing a repository method. The returned function
+// records the duration and is meant to be deferred.
+func ObserveQuery(repository string, method string) func() {
+	start := time.Now()
+
+	return func() {
+		queryDuration.WithLabelValues(repository, method).Observe(time.Since(start).Seconds())
+	}
+}
+
+// InstrumentTransaction wraps a WithTransaction callback. WithTransaction
+// runs the callback again on transient errors, so every call after the first
+// is counted as a retry.
+func InstrumentTransaction(operation string, fn func(ctx context.Context) (any, error)) func(ctx context.Context) (any, error) {
+	attempts := 0
+
+	return func(ctx context.Context) (any, error) {
+		attempts++
+		if attempts == 1 {
+			transactions.WithLabelValues(operation).Inc()
+		} else {
+			transactionRetries.WithLabelValues(operation).Inc()
+		}
+
+		return fn(ctx)
+	}
+}
@@ -0,0 +1,422 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/enxg/skyticket/internal/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+func createEventIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("events").Indexes().CreateMany(ctx, []mongo.IndexModel{{
+		Keys: bson.D{
+			{Key: "name", Value: "text"},
+			{Key: "tags", Value: "text"},
+			{Key: "category", Value: "text"},
+			{Key: "venue", Value: "text"},
+		},
+		Options: options.Index().
+			SetName("events_text").
+			SetDefaultLanguage("none").
+			SetWeights(bson.D{
+				{Key: "name", Value: 10},
+				{Key: "tags", Value: 5},
+				{Key: "category", Value: 3},
+				{Key: "venue", Value: 2},
+			}),
+	}, {
+		Keys:    bson.D{{Key: "location", Value: "2dsphere"}},
+		Options: options.Index().SetName("events_location"),
+	}})
+	return err
+}
+
+func createTicketSeatIndex(ctx context.Context, db *mongo.Database) error {
+	err := checkDuplicates(ctx, db.Collection("tickets"), bson.M{}, "event_id", "seat_number")
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Collection("tickets").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "event_id", Value: 1},
+			{Key: "seat_number", Value: 1},
+		},
+		Options: options.Index().
+			SetName("tickets_event_seat").
+			SetUnique(true),
+	})
+	return err
+}
+
+// createReservationTicketIndex allows any number of cancelled reservations per
+// ticket, but only one that is still current.
+func createReservationTicketIndex(ctx context.Context, db *mongo.Database) error {
+	err := checkDuplicates(ctx, db.Collection("reservations"), bson.M{
+		"status": bson.M{"$in": bson.A{
+			models.ReservationStatusActive,
+			models.ReservationStatusCheckedIn,
+			models.ReservationStatusExpired,
+		}},
+	}, "ticket_id")
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Collection("reservations").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "ticket_id", Value: 1}},
+		Options: options.Index().
+			SetName("reservations_current_ticket").
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{
+				"status": bson.M{"$in": bson.A{
+					models.ReservationStatusActive,
+					models.ReservationStatusCheckedIn,
+					models.ReservationStatusExpired,
+				}},
+			}),
+	})
+	return err
+}
+
+func createLookupIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("reservations").Indexes().CreateMany(ctx, []mongo.IndexModel{{
+		Keys: bson.D{
+			{Key: "event_id", Value: 1},
+			{Key: "token", Value: 1},
+		},
+		Options: options.Index().SetName("reservations_event_token"),
+	}, {
+		Keys: bson.D{
+			{Key: "event_id", Value: 1},
+			{Key: "ticket_id", Value: 1},
+		},
+		Options: options.Index().SetName("reservations_event_ticket"),
+	}})
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Collection("listings").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "ticket_id", Value: 1},
+			{Key: "status", Value: 1},
+		},
+		Options: options.Index().SetName("listings_ticket_status"),
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Collection("events").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "status", Value: 1},
+			{Key: "date", Value: 1},
+		},
+		Options: options.Index().SetName("events_status_date"),
+	})
+	return err
+}
+
+// backfillLegacyEvents gives events created before the lifecycle and timezone
+// support a status and a UTC local date, so they keep showing up in listings.
+func backfillLegacyEvents(ctx context.Context, db *mongo.Database) error {
+	events := db.Collection("events")
+
+	_, err := events.UpdateMany(ctx, bson.M{"status": bson.M{"$exists": false}}, bson.A{
+		bson.M{"$set": bson.M{
+			"status": bson.M{"$cond": bson.A{
+				bson.M{"$lt": bson.A{"$date", "$$NOW"}},
+				models.EventStatusCompleted,
+				models.EventStatusOnSale,
+			}},
+		}},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = events.UpdateMany(ctx, bson.M{
+		"local_date": bson.M{"$exists": false},
+		"timezone":   bson.M{"$exists": false},
+	}, bson.A{
+		bson.M{"$set": bson.M{
+			"timezone": "UTC",
+			"local_date": bson.M{"$dateToString": bson.M{
+				"format": "%Y-%m-%dT%H:%M:%SZ",
+				"date":   "$date",
+			}},
+		}},
+	})
+	return err
+}
+
+func createRateLimitExpiryIndex(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("rate_limits").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "expires_at", Value: 1}},
+		Options: options.Index().
+			SetName("rate_limits_expiry").
+			SetExpireAfterSeconds(0),
+	})
+	return err
+}
+
+// createQueueIndexes keeps queue positions unique per event and removes
+// admitted entries an hour after their admission expired, so that late
+// requests still get an expiry error rather than an unknown token.
+func createQueueIndexes(ctx context.Context, db *mongo.Database) error {
+	_, err := db.Collection("queue_entries").Indexes().CreateMany(ctx, []mongo.IndexModel{
+		{
+			Keys: bson.D{
+				{Key: "event_id", Value: 1},
+				{Key: "position", Value: 1},
+			},
+			Options: options.Index().
+				SetName("queue_entries_event_position").
+				SetUnique(true),
+		},
+		{
+			Keys: bson.D{{Key: "expires_at", Value: 1}},
+			Options: options.Index().
+				SetName("queue_entries_expiry").
+				SetExpireAfterSeconds(3600),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Collection("queues").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "enabled", Value: 1},
+			{Key: "next_admission_at", Value: 1},
+		},
+		Options: options.Index().SetName("queues_enabled_next_admission"),
+	})
+	return err
+}
+
+// backfillTicketCoordinates parses the row and seat of existing tickets from
+// their seat numbers and indexes them for seat adjacency lookups.
+func backfillTicketCoordinates(ctx context.Context, db *mongo.Database) error {
+	tickets := db.Collection("tickets")
+
+	cursor, err := tickets.Find(ctx, bson.M{
+		"row":  bson.M{"$exists": false},
+		"seat": bson.M{"$exists": false},
+	}, options.Find().SetProjection(bson.M{"seat_number": 1}))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var writes []mongo.WriteModel
+	for cursor.Next(ctx) {
+		var ticket models.Ticket
+		err = cursor.Decode(&ticket)
+		if err != nil {
+			return err
+		}
+
+		ticket.Locate()
+		if ticket.Row == "" {
+			continue
+		}
+
+		writes = append(writes, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": ticket.ID}).
+			SetUpdate(bson.M{"$set": bson.M{"row": ticket.Row, "seat": ticket.Seat}}))
+	}
+	if err := cursor.Err(); err != nil {
+		return err
+	}
+
+	if len(writes) > 0 {
+		_, err = tickets.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tickets.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "event_id", Value: 1},
+			{Key: "row", Value: 1},
+			{Key: "seat", Value: 1},
+		},
+		Options: options.Index().SetName("tickets_event_row_seat"),
+	})
+	return err
+}
+
+// supportGeneralAdmission narrows the unique seat and reservation indexes to
+// seated tickets, since a general admission ticket has no seat number and any
+// number of current reservations. The new indexes are built before the old
+// ones are dropped so that uniqueness is enforced throughout.
+func supportGeneralAdmission(ctx context.Context, db *mongo.Database) error {
+	reservations := db.Collection("reservations")
+
+	_, err := reservations.UpdateMany(ctx, bson.M{
+		"ticket_type": bson.M{"$exists": false},
+	}, bson.M{"$set": bson.M{"ticket_type": models.TicketTypeSeated}})
+	if err != nil {
+		return err
+	}
+
+	_, err = reservations.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "ticket_id", Value: 1}},
+		Options: options.Index().
+			SetName("reservations_current_seat").
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{
+				"status": bson.M{"$in": bson.A{
+					models.ReservationStatusActive,
+					models.ReservationStatusCheckedIn,
+					models.ReservationStatusExpired,
+				}},
+				"ticket_type": models.TicketTypeSeated,
+			}),
+	})
+	if err != nil {
+		return err
+	}
+
+	err = dropIndex(ctx, reservations, "reservations_current_ticket")
+	if err != nil {
+		return err
+	}
+
+	tickets := db.Collection("tickets")
+
+	err = checkDuplicates(ctx, tickets, bson.M{
+		"seat_number": bson.M{"$type": "string"},
+	}, "event_id", "seat_number")
+	if err != nil {
+		return err
+	}
+
+	_, err = tickets.Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{
+			{Key: "event_id", Value: 1},
+			{Key: "seat_number", Value: 1},
+		},
+		Options: options.Index().
+			SetName("tickets_event_seat_number").
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{
+				"seat_number": bson.M{"$type": "string"},
+			}),
+	})
+	if err != nil {
+		return err
+	}
+
+	return dropIndex(ctx, tickets, "tickets_event_seat")
+}
+
+// createActiveListingIndex allows any number of sold or cancelled listings per
+// ticket, but only one that is still for sale.
+func createActiveListingIndex(ctx context.Context, db *mongo.Database) error {
+	err := checkDuplicates(ctx, db.Collection("listings"), bson.M{
+		"status": models.ListingStatusActive,
+	}, "ticket_id")
+	if err != nil {
+		return err
+	}
+
+	_, err = db.Collection("listings").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys: bson.D{{Key: "ticket_id", Value: 1}},
+		Options: options.Index().
+			SetName("listings_active_ticket").
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{
+				"status": models.ListingStatusActive,
+			}),
+	})
+	return err
+}
+
+// dropIndex drops the named index, ignoring indexes that no longer exist.
+func dropIndex(ctx context.Context, collection *mongo.Collection, name string) error {
+	err := collection.Indexes().DropOne(ctx, name)
+
+	var cmdErr mongo.CommandError
+	if errors.As(err, &cmdErr) && cmdErr.Name == "IndexNotFound" {
+		return nil
+	}
+
+	return err
+}
+
+// maxReportedDuplicates bounds how many duplicates checkDuplicates lists.
+const maxReportedDuplicates = 20
+
+// checkDuplicates fails with a list of the documents matching filter that
+// share the given keys, since a unique index over them cannot be built. Older
+// versions could create such duplicates under concurrent requests; they have
+// to be removed by hand before migrating again.
+func checkDuplicates(ctx context.Context, collection *mongo.Collection, filter bson.M, keys ...string) error {
+	group := bson.M{}
+	for _, key := range keys {
+		group[key] = "$" + key
+	}
+
+	cursor, err := collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   group,
+			"ids":   bson.M{"$push": "$_id"},
+			"count": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$match", Value: bson.M{"count": bson.M{"$gt": 1}}}},
+		{{Key: "$sort", Value: bson.M{"count": -1}}},
+		{{Key: "$limit", Value: maxReportedDuplicates + 1}},
+	})
+	if err != nil {
+		return err
+	}
+
+	var duplicates []struct {
+		Key bson.M          `bson:"_id"`
+		IDs []bson.ObjectID `bson:"ids"`
+	}
+	if err := cursor.All(ctx, &duplicates); err != nil {
+		return err
+	}
+
+	if len(duplicates) == 0 {
+		return nil
+	}
+
+	lines := make([]string, 0, len(duplicates))
+	for i, d := range duplicates {
+		if i == maxReportedDuplicates {
+			lines = append(lines, "...")
+			break
+		}
+
+		fields := make([]string, len(keys))
+		for j, key := range keys {
+			value := d.Key[key]
+			if id, ok := value.(bson.ObjectID); ok {
+				value = id.Hex()
+			}
+			fields[j] = fmt.Sprintf("%s=%v", key, value)
+		}
+
+		ids := make([]string, len(d.IDs))
+		for j, id := range d.IDs {
+			ids[j] = id.Hex()
+		}
+
+		lines = append(lines, fmt.Sprintf("%s: %s", strings.Join(fields, " "), strings.Join(ids, ", ")))
+	}
+
+	return fmt.Errorf("%s has duplicates that prevent a unique index on %s; keep one document of each and run migrate again:\n%s",
+		collection.Name(), strings.Join(keys, ", "), strings.Join(lines, "\n"))
+}
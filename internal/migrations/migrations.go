@@ -0,0 +1,162 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+const collectionName = "schema_migrations"
+
+// Migration is a single, numbered schema change. Migrations are applied in
+// version order and must be idempotent: two instances starting at the same
+// time may both run a pending migration before either records it.
+type Migration struct {
+	Version     int
+	Description string
+	Up          func(ctx context.Context, db *mongo.Database) error
+}
+
+type AppliedMigration struct {
+	Version     int       `bson:"_id"`
+	Description string    `bson:"description"`
+	AppliedAt   time.Time `bson:"applied_at"`
+}
+
+type Status struct {
+	Migration Migration
+	AppliedAt *time.Time
+}
+
+// All lists every migration in version order.
+var All = []Migration{
+	{
+		Version:     1,
+		Description: "create event search and location indexes",
+		Up:          createEventIndexes,
+	},
+	{
+		Version:     2,
+		Description: "enforce unique seat numbers per event",
+		Up:          createTicketSeatIndex,
+	},
+	{
+		Version:     3,
+		Description: "enforce a single current reservation per ticket",
+		Up:          createReservationTicketIndex,
+	},
+	{
+		Version:     4,
+		Description: "create lookup indexes for reservations, listings and events",
+		Up:          createLookupIndexes,
+	},
+	{
+		Version:     5,
+		Description: "backfill lifecycle status and local dates of legacy events",
+		Up:          backfillLegacyEvents,
+	},
+	{
+		Version:     6,
+		Description: "expire idle rate limit buckets",
+		Up:          createRateLimitExpiryIndex,
+	},
+	{
+		Version:     7,
+		Description: "create virtual queue indexes",
+		Up:          createQueueIndexes,
+	},
+	{
+		Version:     8,
+		Description: "store ticket rows and seats",
+		Up:          backfillTicketCoordinates,
+	},
+	{
+		Version:     9,
+		Description: "support general admission tickets",
+		Up:          supportGeneralAdmission,
+	},
+	{
+		Version:     10,
+		Description: "enforce a single active listing per ticket",
+		Up:          createActiveListingIndex,
+	},
+}
+
+// Run applies all pending migrations and returns the ones it applied.
+func Run(ctx context.Context, db *mongo.Database) ([]Migration, error) {
+	applied, err := appliedVersions(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	var ran []Migration
+	for _, m := range All {
+		if _, ok := applied[m.Version]; ok {
+			continue
+		}
+
+		err := m.Up(ctx, db)
+		if err != nil {
+			return ran, fmt.Errorf("migration %d (%s): %w", m.Version, m.Description, err)
+		}
+
+		_, err = db.Collection(collectionName).InsertOne(ctx, AppliedMigration{
+			Version:     m.Version,
+			Description: m.Description,
+			AppliedAt:   time.Now(),
+		})
+		if err != nil && !mongo.IsDuplicateKeyError(err) {
+			return ran, err
+		}
+
+		ran = append(ran, m)
+	}
+
+	return ran, nil
+}
+
+// Statuses reports every known migration along with when it was applied.
+func Statuses(ctx context.Context, db *mongo.Database) ([]Status, error) {
+	applied, err := appliedVersions(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]Status, len(All))
+	for i, m := range All {
+		statuses[i].Migration = m
+		if a, ok := applied[m.Version]; ok {
+			statuses[i].AppliedAt = &a.AppliedAt
+		}
+	}
+
+	return statuses, nil
+}
+
+func appliedVersions(ctx context.Context, db *mongo.Database) (map[int]AppliedMigration, error) {
+	if !slices.IsSortedFunc(All, func(a, b Migration) int { return a.Version - b.Version }) {
+		return nil, errors.New("migrations are not in version order")
+	}
+
+	cursor, err := db.Collection(collectionName).Find(ctx, bson.M{})
+	if err != nil {
+		return nil, err
+	}
+
+	var applied []AppliedMigration
+	if err := cursor.All(ctx, &applied); err != nil {
+		return nil, err
+	}
+
+	versions := make(map[int]AppliedMigration, len(applied))
+	for _, a := range applied {
+		versions[a.Version] = a
+	}
+
+	return versions, nil
+}
@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+const EventArchiveVersion = 1
+
+type EventArchive struct {
+	Version      int           `json:"version" example:"1" extensions:"x-order=0"`
+	ExportedAt   time.Time     `json:"exported_at" example:"2025-11-30T09:00:00Z" extensions:"x-order=1"`
+	Event        Event         `json:"event" extensions:"x-order=2"`
+	Tickets      []Ticket      `json:"tickets" extensions:"x-order=3"`
+	Reservations []Reservation `json:"reservations" extensions:"x-order=4"`
+}
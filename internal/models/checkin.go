@@ -0,0 +1,9 @@
+package models
+
+import "go.mongodb.org/mongo-driver/v2/bson"
+
+type CheckInStats struct {
+	EventID   bson.ObjectID `json:"event_id" example:"68f0c6a8f5673dc0ec646731" extensions:"x-order=0"`
+	Reserved  int64         `json:"reserved" example:"1250" extensions:"x-order=1"`
+	CheckedIn int64         `json:"checked_in" example:"842" extensions:"x-order=2"`
+}
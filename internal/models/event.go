@@ -6,9 +6,60 @@ import (
 	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
+type EventStatus string
+
+const (
+	EventStatusDraft     EventStatus = "DRAFT"
+	EventStatusPublished EventStatus = "PUBLISHED"
+	EventStatusOnSale    EventStatus = "ON_SALE"
+	EventStatusSoldOut   EventStatus = "SOLD_OUT"
+	EventStatusCancelled EventStatus = "CANCELLED"
+	EventStatusCompleted EventStatus = "COMPLETED"
+)
+
 type Event struct {
-	ID    bson.ObjectID `json:"id,omitempty" bson:"_id,omitempty" example:"68f0c6a8f5673dc0ec646731" extensions:"x-order=0"`
-	Name  string        `json:"name,omitempty" bson:"name,omitempty" example:"FORMULA 1 ETIHAD AIRWAYS ABU DHABI GRAND PRIX 2025" extensions:"x-order=1"`
-	Date  time.Time     `json:"date,omitempty" bson:"date,omitempty" example:"2025-12-07T19:00:00Z" extensions:"x-order=2"`
-	Venue string        `json:"venue,omitempty" bson:"venue,omitempty" example:"YTÜ Davutpaşa Tarihi Hamam" extensions:"x-order=3"`
+	ID             bson.ObjectID `json:"id,omitempty" bson:"_id,omitempty" example:"68f0c6a8f5673dc0ec646731" extensions:"x-order=0"`
+	Name           string        `json:"name,omitempty" bson:"name,omitempty" example:"FORMULA 1 ETIHAD AIRWAYS ABU DHABI GRAND PRIX 2025" extensions:"x-order=1"`
+	Date           time.Time     `json:"date,omitempty" bson:"date,omitempty" example:"2025-12-07T13:00:00Z" extensions:"x-order=2"`
+	LocalDate      string        `json:"local_date,omitempty" bson:"local_date,omitempty" example:"2025-12-07T17:00:00+04:00" extensions:"x-order=3"`
+	Timezone       string        `json:"timezone,omitempty" bson:"timezone,omitempty" example:"Asia/Dubai" extensions:"x-order=4"`
+	Venue          string        `json:"venue,omitempty" bson:"venue,omitempty" example:"YTÜ Davutpaşa Tarihi Hamam" extensions:"x-order=5"`
+	ResalePriceCap int           `json:"resale_price_cap,omitempty" bson:"resale_price_cap,omitempty" example:"110" extensions:"x-order=6"`
+	Status         EventStatus   `json:"status,omitempty" bson:"status,omitempty" example:"ON_SALE" extensions:"x-order=7"`
+	SeriesID       bson.ObjectID `json:"series_id,omitzero" bson:"series_id,omitempty" example:"68f9b2d3e4f5a6b7c8d9e0f1" extensions:"x-order=8"`
+	Category       string        `json:"category,omitempty" bson:"category,omitempty" example:"Motorsport" extensions:"x-order=9"`
+	Tags           []string      `json:"tags,omitempty" bson:"tags,omitempty" example:"formula 1,racing" extensions:"x-order=10"`
+	Address        *Address      `json:"address,omitempty" bson:"address,omitempty" extensions:"x-order=11"`
+	Location       *GeoPoint     `json:"location,omitempty" bson:"location,omitempty" extensions:"x-order=12"`
+	DistanceKm     float64       `json:"distance_km,omitempty" bson:"distance_km,omitempty" example:"3.42" extensions:"x-order=13"`
+	SeatingRules   *SeatingRules `json:"seating_rules,omitempty" bson:"seating_rules,omitempty" extensions:"x-order=14"`
+}
+
+// SeatingRules restrict which seats can be reserved together.
+type SeatingRules struct {
+	// NoSingleGaps rejects reservations that would leave a single empty seat
+	// between two occupied seats in a row.
+	NoSingleGaps bool `json:"no_single_gaps" bson:"no_single_gaps" example:"true"`
+}
+
+const DefaultResalePriceCap = 100
+
+func (e Event) NoSingleGaps() bool {
+	return e.SeatingRules != nil && e.SeatingRules.NoSingleGaps
+}
+
+// EffectiveStatus is the event's lifecycle status. Events created before the
+// lifecycle existed have none and were always on sale.
+func (e Event) EffectiveStatus() EventStatus {
+	if e.Status == "" {
+		return EventStatusOnSale
+	}
+	return e.Status
+}
+
+func (e Event) EffectiveResalePriceCap() int {
+	if e.ResalePriceCap == 0 {
+		return DefaultResalePriceCap
+	}
+	return e.ResalePriceCap
 }
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type ListingStatus string
+
+const (
+	ListingStatusActive    ListingStatus = "ACTIVE"
+	ListingStatusSold      ListingStatus = "SOLD"
+	ListingStatusCancelled ListingStatus = "CANCELLED"
+)
+
+type Listing struct {
+	ID            bson.ObjectID `json:"id,omitempty" bson:"_id,omitempty" example:"68f8a1c2d4e5f60718293a4b" extensions:"x-order=0"`
+	EventID       bson.ObjectID `json:"event_id,omitempty" bson:"event_id,omitempty" example:"68f0c6a8f5673dc0ec646731" extensions:"x-order=1"`
+	TicketID      bson.ObjectID `json:"ticket_id,omitempty" bson:"ticket_id,omitempty" example:"68f2ab0516a352dc8f40c543" extensions:"x-order=2"`
+	ReservationID bson.ObjectID `json:"reservation_id,omitempty" bson:"reservation_id,omitempty" example:"68f4fea9990e605d6589b5f3" extensions:"x-order=3"`
+	SellerName    string        `json:"seller_name,omitempty" bson:"seller_name,omitempty" example:"Lewis Hamilton" extensions:"x-order=4"`
+	SellerToken   string        `json:"-" bson:"seller_token,omitempty"`
+	Price         int           `json:"price,omitempty" bson:"price,omitempty" example:"5499" extensions:"x-order=5"`
+	Status        ListingStatus `json:"status,omitempty" bson:"status,omitempty" example:"ACTIVE" extensions:"x-order=6"`
+	CreatedAt     time.Time     `json:"created_at,omitempty" bson:"created_at,omitempty" example:"2025-11-20T10:30:00Z" extensions:"x-order=7"`
+	BuyerName     string        `json:"buyer_name,omitempty" bson:"buyer_name,omitempty" example:"Max Verstappen" extensions:"x-order=8"`
+	SoldAt        *time.Time    `json:"sold_at,omitempty" bson:"sold_at,omitempty" example:"2025-11-21T08:45:00Z" extensions:"x-order=9"`
+}
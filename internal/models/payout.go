@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type PayoutStatus string
+
+const (
+	PayoutStatusPending PayoutStatus = "PENDING"
+	PayoutStatusPaid    PayoutStatus = "PAID"
+)
+
+type Payout struct {
+	ID         bson.ObjectID `json:"id,omitempty" bson:"_id,omitempty" example:"68f8a3e7d4e5f60718293a4c" extensions:"x-order=0"`
+	EventID    bson.ObjectID `json:"event_id,omitempty" bson:"event_id,omitempty" example:"68f0c6a8f5673dc0ec646731" extensions:"x-order=1"`
+	ListingID  bson.ObjectID `json:"listing_id,omitempty" bson:"listing_id,omitempty" example:"68f8a1c2d4e5f60718293a4b" extensions:"x-order=2"`
+	SellerName string        `json:"seller_name,omitempty" bson:"seller_name,omitempty" example:"Lewis Hamilton" extensions:"x-order=3"`
+	Amount     int           `json:"amount,omitempty" bson:"amount,omitempty" example:"5499" extensions:"x-order=4"`
+	Status     PayoutStatus  `json:"status,omitempty" bson:"status,omitempty" example:"PENDING" extensions:"x-order=5"`
+	CreatedAt  time.Time     `json:"created_at,omitempty" bson:"created_at,omitempty" example:"2025-11-21T08:45:00Z" extensions:"x-order=6"`
+}
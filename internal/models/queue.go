@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type QueueEntryStatus string
+
+const (
+	QueueEntryStatusWaiting  QueueEntryStatus = "WAITING"
+	QueueEntryStatusAdmitted QueueEntryStatus = "ADMITTED"
+)
+
+// EventQueue is the waiting room of an event. Every IntervalSeconds the next
+// BatchSize positions are admitted.
+type EventQueue struct {
+	EventID         bson.ObjectID `json:"event_id" bson:"_id" example:"68f0c6a8f5673dc0ec646731" extensions:"x-order=0"`
+	Enabled         bool          `json:"enabled" bson:"enabled" example:"true" extensions:"x-order=1"`
+	BatchSize       int           `json:"batch_size" bson:"batch_size" example:"100" extensions:"x-order=2"`
+	IntervalSeconds int           `json:"interval_seconds" bson:"interval_seconds" example:"30" extensions:"x-order=3"`
+	LastPosition    int64         `json:"last_position" bson:"last_position" example:"4210" extensions:"x-order=4"`
+	AdmittedUpTo    int64         `json:"admitted_up_to" bson:"admitted_up_to" example:"1200" extensions:"x-order=5"`
+	NextAdmissionAt time.Time     `json:"next_admission_at" bson:"next_admission_at" example:"2025-11-20T10:30:30Z" extensions:"x-order=6"`
+	Waiting         int64         `json:"waiting" bson:"-" example:"3010" extensions:"x-order=7"`
+}
+
+type QueueEntry struct {
+	ID         bson.ObjectID    `json:"-" bson:"_id,omitempty"`
+	EventID    bson.ObjectID    `json:"event_id" bson:"event_id" example:"68f0c6a8f5673dc0ec646731" extensions:"x-order=0"`
+	Position   int64            `json:"position" bson:"position" example:"1342" extensions:"x-order=1"`
+	Status     QueueEntryStatus `json:"status" bson:"status" example:"WAITING" extensions:"x-order=2"`
+	JoinedAt   time.Time        `json:"joined_at" bson:"joined_at" example:"2025-11-20T10:00:00Z" extensions:"x-order=3"`
+	AdmittedAt *time.Time       `json:"admitted_at,omitempty" bson:"admitted_at,omitempty" example:"2025-11-20T10:30:30Z" extensions:"x-order=4"`
+	ExpiresAt  *time.Time       `json:"expires_at,omitempty" bson:"expires_at,omitempty" example:"2025-11-20T10:40:30Z" extensions:"x-order=5"`
+}
+
+// QueuePosition is what a queued user sees while waiting. The token is only
+// returned when joining the queue.
+type QueuePosition struct {
+	Token                string           `json:"token,omitempty" example:"aPDGqPVnPcDsZGRGaPDGqPVnPcDsZGRG.kq2mX6pVn5cYxN0dEzLw1r6y3gkVtqZJmF2eG8hQpUc" extensions:"x-order=0"`
+	EventID              bson.ObjectID    `json:"event_id" example:"68f0c6a8f5673dc0ec646731" extensions:"x-order=1"`
+	Position             int64            `json:"position" example:"1342" extensions:"x-order=2"`
+	Status               QueueEntryStatus `json:"status" example:"WAITING" extensions:"x-order=3"`
+	Ahead                int64            `json:"ahead" example:"141" extensions:"x-order=4"`
+	EstimatedWaitSeconds int              `json:"estimated_wait_seconds" example:"60" extensions:"x-order=5"`
+	ExpiresAt            *time.Time       `json:"expires_at,omitempty" example:"2025-11-20T10:40:30Z" extensions:"x-order=6"`
+}
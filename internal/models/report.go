@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type EventStats struct {
+	EventID            bson.ObjectID          `json:"event_id" example:"68f0c6a8f5673dc0ec646731" extensions:"x-order=0"`
+	TotalTickets       int64                  `json:"total_tickets" example:"1500" extensions:"x-order=1"`
+	TicketsByStatus    map[TicketStatus]int64 `json:"tickets_by_status" extensions:"x-order=2"`
+	GrossRevenue       int64                  `json:"gross_revenue" example:"6248750" extensions:"x-order=3"`
+	AveragePrice       int64                  `json:"average_price" example:"4999" extensions:"x-order=4"`
+	SellThrough        float64                `json:"sell_through" example:"83.33" extensions:"x-order=5"`
+	Cancellations      int64                  `json:"cancellations" example:"37" extensions:"x-order=6"`
+	ReservationsPerDay []DailyReservations    `json:"reservations_per_day" extensions:"x-order=7"`
+}
+
+type TicketStatusStats struct {
+	Status  TicketStatus `bson:"_id"`
+	Count   int64        `bson:"count"`
+	Revenue int64        `bson:"revenue"`
+}
+
+type DailyReservations struct {
+	Day           string `json:"day" bson:"_id" example:"2025-11-02" extensions:"x-order=0"`
+	Reservations  int64  `json:"reservations" bson:"reservations" example:"412" extensions:"x-order=1"`
+	Cancellations int64  `json:"cancellations" bson:"cancellations" example:"9" extensions:"x-order=2"`
+}
+
+type SalesSummary struct {
+	From          string              `json:"from" example:"2025-12-01" extensions:"x-order=0"`
+	To            string              `json:"to" example:"2025-12-31" extensions:"x-order=1"`
+	Events        int64               `json:"events" example:"12" extensions:"x-order=2"`
+	TotalTickets  int64               `json:"total_tickets" example:"18000" extensions:"x-order=3"`
+	TicketsSold   int64               `json:"tickets_sold" example:"15420" extensions:"x-order=4"`
+	GrossRevenue  int64               `json:"gross_revenue" example:"77084580" extensions:"x-order=5"`
+	SellThrough   float64             `json:"sell_through" example:"85.67" extensions:"x-order=6"`
+	Cancellations int64               `json:"cancellations" example:"311" extensions:"x-order=7"`
+	Breakdown     []EventSalesSummary `json:"breakdown" extensions:"x-order=8"`
+}
+
+type EventSalesSummary struct {
+	EventID       bson.ObjectID `json:"event_id" bson:"event_id" example:"68f0c6a8f5673dc0ec646731" extensions:"x-order=0"`
+	Name          string        `json:"name" bson:"name" example:"FORMULA 1 ETIHAD AIRWAYS ABU DHABI GRAND PRIX 2025" extensions:"x-order=1"`
+	Date          time.Time     `json:"date" bson:"date" example:"2025-12-07T13:00:00Z" extensions:"x-order=2"`
+	Status        EventStatus   `json:"status" bson:"status" example:"SOLD_OUT" extensions:"x-order=3"`
+	TotalTickets  int64         `json:"total_tickets" bson:"total_tickets" example:"1500" extensions:"x-order=4"`
+	TicketsSold   int64         `json:"tickets_sold" bson:"tickets_sold" example:"1500" extensions:"x-order=5"`
+	GrossRevenue  int64         `json:"gross_revenue" bson:"gross_revenue" example:"7498500" extensions:"x-order=6"`
+	Cancellations int64         `json:"cancellations" bson:"cancellations" example:"21" extensions:"x-order=7"`
+}
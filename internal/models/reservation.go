@@ -10,7 +10,9 @@ type ReservationStatus string
 
 const (
 	ReservationStatusActive    ReservationStatus = "ACTIVE"
+	ReservationStatusCheckedIn ReservationStatus = "CHECKED_IN"
 	ReservationStatusCancelled ReservationStatus = "CANCELLED"
+	ReservationStatusExpired   ReservationStatus = "EXPIRED"
 )
 
 type Reservation struct {
@@ -20,4 +22,62 @@ type Reservation struct {
 	CustomerName    string            `json:"customer_name,omitempty" bson:"customer_name,omitempty" example:"Lewis Hamilton" extensions:"x-order=3"`
 	ReservationDate time.Time         `json:"reservation_date,omitempty" bson:"reservation_date,omitempty" example:"2025-10-19T15:00:00Z" extensions:"x-order=4"`
 	Status          ReservationStatus `json:"status,omitempty" bson:"status,omitempty" example:"ACTIVE" extensions:"x-order=5"`
+	Token           string            `json:"token,omitempty" bson:"token,omitempty" example:"9f86d081884c7d659a2feaa0c55ad015" extensions:"x-order=6"`
+	CheckedInAt     *time.Time        `json:"checked_in_at,omitempty" bson:"checked_in_at,omitempty" example:"2025-12-07T18:12:43Z" extensions:"x-order=7"`
+	PendingTransfer *PendingTransfer  `json:"pending_transfer,omitempty" bson:"pending_transfer,omitempty" extensions:"x-order=8"`
+	Transfers       []TransferRecord  `json:"transfers,omitempty" bson:"transfers,omitempty" extensions:"x-order=9"`
+	CancelledAt     *time.Time        `json:"cancelled_at,omitempty" bson:"cancelled_at,omitempty" example:"2025-11-02T09:14:00Z" extensions:"x-order=10"`
+	// TicketType is copied from the ticket. Only seated tickets are limited to
+	// one current reservation.
+	TicketType TicketType `json:"ticket_type,omitempty" bson:"ticket_type,omitempty" example:"SEATED" extensions:"x-order=11"`
+	// Quantity is the number of general admission places held. It is unset
+	// for seated reservations.
+	Quantity int `json:"quantity,omitempty" bson:"quantity,omitempty" example:"4" extensions:"x-order=12"`
+}
+
+// Places is the number of people admitted by the reservation.
+func (r Reservation) Places() int {
+	return max(r.Quantity, 1)
+}
+
+// BestAvailableReservation is the result of reserving several tickets at once.
+// Adjacent reports whether every ticket is in the same row with consecutive
+// seat numbers.
+type BestAvailableReservation struct {
+	Adjacent     bool          `json:"adjacent" example:"true" extensions:"x-order=0"`
+	TotalPrice   int           `json:"total_price" example:"9998" extensions:"x-order=1"`
+	Tickets      []Ticket      `json:"tickets" extensions:"x-order=2"`
+	Reservations []Reservation `json:"reservations" extensions:"x-order=3"`
+}
+
+type ManifestEntry struct {
+	SeatNumber string     `bson:"seat_number"`
+	Category   string     `bson:"category"`
+	TicketType TicketType `bson:"ticket_type"`
+	// Places is the number of people the reservation admits.
+	Places          int               `bson:"places"`
+	CustomerName    string            `bson:"customer_name"`
+	ReservationDate time.Time         `bson:"reservation_date"`
+	Status          ReservationStatus `bson:"status"`
+}
+
+type PendingTransfer struct {
+	RecipientName string    `json:"recipient_name,omitempty" bson:"recipient_name,omitempty" example:"Max Verstappen" extensions:"x-order=0"`
+	Code          string    `json:"-" bson:"code,omitempty"`
+	InitiatedAt   time.Time `json:"initiated_at,omitempty" bson:"initiated_at,omitempty" example:"2025-11-20T10:30:00Z" extensions:"x-order=1"`
+	ExpiresAt     time.Time `json:"expires_at,omitempty" bson:"expires_at,omitempty" example:"2025-11-22T10:30:00Z" extensions:"x-order=2"`
+}
+
+type TransferMethod string
+
+const (
+	TransferMethodTransfer TransferMethod = "TRANSFER"
+	TransferMethodResale   TransferMethod = "RESALE"
+)
+
+type TransferRecord struct {
+	FromCustomerName string         `json:"from_customer_name,omitempty" bson:"from_customer_name,omitempty" example:"Lewis Hamilton" extensions:"x-order=0"`
+	ToCustomerName   string         `json:"to_customer_name,omitempty" bson:"to_customer_name,omitempty" example:"Max Verstappen" extensions:"x-order=1"`
+	TransferredAt    time.Time      `json:"transferred_at,omitempty" bson:"transferred_at,omitempty" example:"2025-11-21T08:45:00Z" extensions:"x-order=2"`
+	Method           TransferMethod `json:"method,omitempty" bson:"method,omitempty" example:"TRANSFER" extensions:"x-order=3"`
 }
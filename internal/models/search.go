@@ -0,0 +1,22 @@
+package models
+
+type EventSearchResult struct {
+	Results []ScoredEvent     `json:"results" bson:"results" extensions:"x-order=0"`
+	Facets  EventSearchFacets `json:"facets" bson:",inline" extensions:"x-order=1"`
+}
+
+type ScoredEvent struct {
+	Event `bson:",inline"`
+	Score float64 `json:"score" bson:"score" example:"1.75"`
+}
+
+type EventSearchFacets struct {
+	Categories []FacetCount `json:"categories" bson:"categories" extensions:"x-order=0"`
+	Venues     []FacetCount `json:"venues" bson:"venues" extensions:"x-order=1"`
+	Months     []FacetCount `json:"months" bson:"months" extensions:"x-order=2"`
+}
+
+type FacetCount struct {
+	Value string `json:"value" bson:"_id" example:"Motorsport" extensions:"x-order=0"`
+	Count int64  `json:"count" bson:"count" example:"12" extensions:"x-order=1"`
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+type EventSeries struct {
+	ID             bson.ObjectID  `json:"id,omitempty" bson:"_id,omitempty" example:"68f9b2d3e4f5a6b7c8d9e0f1" extensions:"x-order=0"`
+	Name           string         `json:"name,omitempty" bson:"name,omitempty" example:"The Phantom of the Opera" extensions:"x-order=1"`
+	Venue          string         `json:"venue,omitempty" bson:"venue,omitempty" example:"Zorlu PSM" extensions:"x-order=2"`
+	Start          time.Time      `json:"start,omitempty" bson:"start,omitempty" example:"2025-12-01T17:00:00Z" extensions:"x-order=3"`
+	Timezone       string         `json:"timezone,omitempty" bson:"timezone,omitempty" example:"Europe/Istanbul" extensions:"x-order=4"`
+	Recurrence     string         `json:"recurrence,omitempty" bson:"recurrence,omitempty" example:"FREQ=DAILY;COUNT=30" extensions:"x-order=5"`
+	ResalePriceCap int            `json:"resale_price_cap,omitempty" bson:"resale_price_cap,omitempty" example:"110" extensions:"x-order=6"`
+	Tickets        []SeriesTicket `json:"tickets,omitempty" bson:"tickets,omitempty" extensions:"x-order=7"`
+	Address        *Address       `json:"address,omitempty" bson:"address,omitempty" extensions:"x-order=8"`
+	Location       *GeoPoint      `json:"location,omitempty" bson:"location,omitempty" extensions:"x-order=9"`
+	Category       string         `json:"category,omitempty" bson:"category,omitempty" example:"Musical" extensions:"x-order=10"`
+	Tags           []string       `json:"tags,omitempty" bson:"tags,omitempty" example:"theatre,broadway" extensions:"x-order=11"`
+	SeatingRules   *SeatingRules  `json:"seating_rules,omitempty" bson:"seating_rules,omitempty" extensions:"x-order=12"`
+}
+
+type SeriesTicket struct {
+	SeatNumber string `json:"seat_number,omitempty" bson:"seat_number,omitempty" example:"A12" extensions:"x-order=0"`
+	Price      int    `json:"price,omitempty" bson:"price,omitempty" example:"4999" extensions:"x-order=1"`
+	Category   string `json:"category,omitempty" bson:"category,omitempty" example:"Stalls" extensions:"x-order=2"`
+	Section    string `json:"section,omitempty" bson:"section,omitempty" example:"North Stand" extensions:"x-order=3"`
+	Row        string `json:"row,omitempty" bson:"row,omitempty" example:"A" extensions:"x-order=4"`
+	Seat       int    `json:"seat,omitempty" bson:"seat,omitempty" example:"12" extensions:"x-order=5"`
+	Capacity   int    `json:"capacity,omitempty" bson:"capacity,omitempty" example:"500" extensions:"x-order=6"`
+}
@@ -1,6 +1,9 @@
 package models
 
 import (
+	"strconv"
+	"strings"
+
 	"go.mongodb.org/mongo-driver/v2/bson"
 )
 
@@ -11,6 +14,13 @@ const (
 	TicketStatusReserved  TicketStatus = "RESERVED"
 )
 
+type TicketType string
+
+const (
+	TicketTypeSeated           TicketType = "SEATED"
+	TicketTypeGeneralAdmission TicketType = "GENERAL_ADMISSION"
+)
+
 // TODO: Might add an option to specify currency
 
 type Ticket struct {
@@ -19,4 +29,64 @@ type Ticket struct {
 	SeatNumber string        `json:"seat_number,omitempty" bson:"seat_number,omitempty" example:"A12" extensions:"x-order=2"`
 	Price      int           `json:"price,omitempty" bson:"price,omitempty" example:"4999" extensions:"x-order=3"`
 	Status     TicketStatus  `json:"status,omitempty" bson:"status,omitempty" example:"AVAILABLE" extensions:"x-order=4"`
+	Category   string        `json:"category,omitempty" bson:"category,omitempty" example:"Grandstand" extensions:"x-order=5"`
+	Section    string        `json:"section,omitempty" bson:"section,omitempty" example:"North Stand" extensions:"x-order=6"`
+	Row        string        `json:"row,omitempty" bson:"row,omitempty" example:"A" extensions:"x-order=7"`
+	Seat       int           `json:"seat,omitempty" bson:"seat,omitempty" example:"12" extensions:"x-order=8"`
+	// Type is GENERAL_ADMISSION for unnumbered tickets sold up to Capacity.
+	// Tickets without a type are seated.
+	Type      TicketType `json:"type,omitempty" bson:"type,omitempty" example:"GENERAL_ADMISSION" extensions:"x-order=9"`
+	Capacity  int        `json:"capacity,omitempty" bson:"capacity,omitempty" example:"500" extensions:"x-order=10"`
+	Remaining int        `json:"remaining,omitempty" bson:"remaining,omitempty" example:"212" extensions:"x-order=11"`
+}
+
+func (t Ticket) IsGeneralAdmission() bool {
+	return t.Type == TicketTypeGeneralAdmission
+}
+
+// Locate fills in the row and seat from the seat number when neither is set.
+func (t *Ticket) Locate() {
+	if t.Row != "" || t.Seat != 0 || t.IsGeneralAdmission() {
+		return
+	}
+
+	if row, number, ok := ParseSeatNumber(t.SeatNumber); ok {
+		t.Row = row
+		t.Seat = number
+	}
+}
+
+// Area is the part of the venue the ticket's row belongs to: its section, or
+// its category for tickets without one.
+func (t Ticket) Area() string {
+	if t.Section != "" {
+		return t.Section
+	}
+	return t.Category
+}
+
+// ParseSeatNumber splits a seat number such as "A12" or "AA 7" into its row
+// letters and seat number. Seats that do not follow this layout, such as
+// "GA" or "12", are reported as not ok.
+func ParseSeatNumber(seatNumber string) (row string, number int, ok bool) {
+	i := strings.IndexFunc(seatNumber, func(r rune) bool {
+		return r >= '0' && r <= '9'
+	})
+	if i <= 0 {
+		return "", 0, false
+	}
+
+	row = strings.TrimRight(seatNumber[:i], " -")
+	for _, r := range row {
+		if (r < 'A' || r > 'Z') && (r < 'a' || r > 'z') {
+			return "", 0, false
+		}
+	}
+
+	number, err := strconv.Atoi(seatNumber[i:])
+	if err != nil || row == "" {
+		return "", 0, false
+	}
+
+	return strings.ToUpper(row), number, true
 }
@@ -0,0 +1,22 @@
+package models
+
+type Address struct {
+	Street     string `json:"street,omitempty" bson:"street,omitempty" example:"Davutpaşa Cd. No:127" extensions:"x-order=0"`
+	City       string `json:"city,omitempty" bson:"city,omitempty" example:"İstanbul" extensions:"x-order=1"`
+	PostalCode string `json:"postal_code,omitempty" bson:"postal_code,omitempty" example:"34220" extensions:"x-order=2"`
+	Country    string `json:"country,omitempty" bson:"country,omitempty" example:"TR" extensions:"x-order=3"`
+}
+
+type GeoPoint struct {
+	Type        string    `json:"type" bson:"type" example:"Point" extensions:"x-order=0"`
+	Coordinates []float64 `json:"coordinates" bson:"coordinates" example:"28.8897,41.0247" extensions:"x-order=1"`
+}
+
+// NewGeoPoint builds a GeoJSON point. GeoJSON orders coordinates as
+// longitude first, then latitude.
+func NewGeoPoint(latitude float64, longitude float64) *GeoPoint {
+	return &GeoPoint{
+		Type:        "Point",
+		Coordinates: []float64{longitude, latitude},
+	}
+}
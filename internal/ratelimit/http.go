@@ -0,0 +1,132 @@
+package ratelimit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/enxg/skyticket/internal/config"
+	"github.com/enxg/skyticket/internal/logging"
+	"github.com/gofiber/fiber/v3"
+)
+
+const (
+	HeaderLimit     = "RateLimit-Limit"
+	HeaderRemaining = "RateLimit-Remaining"
+	HeaderReset     = "RateLimit-Reset"
+	APIKeyHeader    = "X-API-Key"
+)
+
+type Rule struct {
+	Name string
+	Rate config.Rate
+	// Key identifies who the request counts against. Requests for which it
+	// returns an empty string are not limited by the rule.
+	Key func(c fiber.Ctx) string
+}
+
+// Middleware checks every rule in order and rejects the request with 429 as
+// soon as one of them is exhausted. The RateLimit-* headers describe the rule
+// closest to its limit. If the store fails the request is let through, since
+// refusing all traffic would be worse than a missed limit.
+func Middleware(store Store, rules ...Rule) fiber.Handler {
+	enabled := make([]Rule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.Rate.Enabled() {
+			enabled = append(enabled, rule)
+		}
+	}
+
+	return func(c fiber.Ctx) error {
+		var (
+			tightest     Result
+			tightestRate config.Rate
+		)
+
+		for _, rule := range enabled {
+			key := rule.Key(c)
+			if key == "" {
+				continue
+			}
+
+			result, err := store.Take(c.Context(), rule.Name+":"+key, rule.Rate)
+			if err != nil {
+				logging.FromContext(c.Context()).Warn().Err(err).Str("rule", rule.Name).Msg("rate limit check failed")
+				continue
+			}
+
+			if !result.Allowed {
+				setHeaders(c, result, rule.Rate)
+				c.Set(fiber.HeaderRetryAfter, strconv.Itoa(seconds(result.RetryAfter)))
+
+				return fiber.NewError(fiber.StatusTooManyRequests, "Too many requests")
+			}
+
+			if !tightestRate.Enabled() || result.Remaining < tightest.Remaining {
+				tightest = result
+				tightestRate = rule.Rate
+			}
+		}
+
+		if tightestRate.Enabled() {
+			setHeaders(c, tightest, tightestRate)
+		}
+
+		return c.Next()
+	}
+}
+
+// ByIP limits requests per client address.
+func ByIP(c fiber.Ctx) string {
+	return c.IP()
+}
+
+// ByAPIKey limits requests per X-API-Key header. Keys are hashed so that they
+// are never stored.
+func ByAPIKey(c fiber.Ctx) string {
+	apiKey := c.Get(APIKeyHeader)
+	if apiKey == "" {
+		return ""
+	}
+
+	return hash(apiKey)
+}
+
+// ByCustomer limits requests per customer_name in the JSON body. Names are
+// normalized and hashed so that they are never stored.
+func ByCustomer(c fiber.Ctx) string {
+	var body struct {
+		CustomerName string `json:"customer_name"`
+	}
+
+	err := json.Unmarshal(c.Body(), &body)
+	if err != nil {
+		return ""
+	}
+
+	name := strings.Join(strings.Fields(strings.ToLower(body.CustomerName)), " ")
+	if name == "" {
+		return ""
+	}
+
+	return hash(name)
+}
+
+func setHeaders(c fiber.Ctx, result Result, rate config.Rate) {
+	c.Set(HeaderLimit, strconv.Itoa(rate.Limit))
+	c.Set(HeaderRemaining, strconv.Itoa(result.Remaining))
+	c.Set(HeaderReset, strconv.Itoa(seconds(result.Reset)))
+}
+
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
+
+func hash(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:16])
+}
@@ -0,0 +1,76 @@
+package ratelimit
+
+import (
+	"context"
+
+	"github.com/enxg/skyticket/internal/config"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type mongoStore struct {
+	collection *mongo.Collection
+}
+
+// NewMongoStore returns a store that keeps buckets in the rate_limits
+// collection so that all instances share them. Buckets are updated atomically
+// using the server's clock and expire through a TTL index once they would be
+// full again.
+func NewMongoStore(db *mongo.Database) Store {
+	return &mongoStore{
+		collection: db.Collection("rate_limits"),
+	}
+}
+
+func (s *mongoStore) Take(ctx context.Context, key string, rate config.Rate) (Result, error) {
+	capacity := float64(rate.Limit)
+	periodMs := float64(rate.Period.Milliseconds())
+
+	update := bson.A{
+		bson.M{"$set": bson.M{
+			"tokens": bson.M{"$min": bson.A{
+				capacity,
+				bson.M{"$add": bson.A{
+					bson.M{"$ifNull": bson.A{"$tokens", capacity}},
+					bson.M{"$multiply": bson.A{
+						bson.M{"$subtract": bson.A{"$$NOW", bson.M{"$ifNull": bson.A{"$updated_at", "$$NOW"}}}},
+						capacity / periodMs,
+					}},
+				}},
+			}},
+			"updated_at": "$$NOW",
+		}},
+		bson.M{"$set": bson.M{
+			"allowed": bson.M{"$gte": bson.A{"$tokens", 1}},
+			"tokens": bson.M{"$cond": bson.A{
+				bson.M{"$gte": bson.A{"$tokens", 1}},
+				bson.M{"$subtract": bson.A{"$tokens", 1}},
+				"$tokens",
+			}},
+			// An empty bucket is full again after one period.
+			"expires_at": bson.M{"$add": bson.A{"$$NOW", rate.Period.Milliseconds()}},
+		}},
+	}
+
+	opts := options.FindOneAndUpdate().
+		SetUpsert(true).
+		SetReturnDocument(options.After)
+
+	var doc struct {
+		Tokens  float64 `bson:"tokens"`
+		Allowed bool    `bson:"allowed"`
+	}
+
+	err := s.collection.FindOneAndUpdate(ctx, bson.M{"_id": key}, update, opts).Decode(&doc)
+	if mongo.IsDuplicateKeyError(err) {
+		// Another request created the bucket at the same time. The retry
+		// updates the existing document.
+		err = s.collection.FindOneAndUpdate(ctx, bson.M{"_id": key}, update, opts).Decode(&doc)
+	}
+	if err != nil {
+		return Result{}, err
+	}
+
+	return newResult(doc.Allowed, doc.Tokens, rate), nil
+}
@@ -0,0 +1,49 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/enxg/skyticket/internal/config"
+)
+
+// Store keeps token buckets. Each bucket holds up to rate.Limit tokens and is
+// refilled at rate.Limit tokens per rate.Period; every request takes one.
+type Store interface {
+	Take(ctx context.Context, key string, rate config.Rate) (Result, error)
+}
+
+type Result struct {
+	Allowed   bool
+	Remaining int
+	// RetryAfter is how long until the next token is available. It is zero
+	// when the request was allowed.
+	RetryAfter time.Duration
+	// Reset is how long until the bucket is full again.
+	Reset time.Duration
+}
+
+// newResult describes a bucket holding tokens after the request was counted.
+func newResult(allowed bool, tokens float64, rate config.Rate) Result {
+	perToken := rate.Period / time.Duration(rate.Limit)
+
+	result := Result{
+		Allowed:   allowed,
+		Remaining: int(math.Floor(tokens)),
+		Reset:     time.Duration((float64(rate.Limit) - tokens) * float64(perToken)),
+	}
+
+	if !allowed {
+		result.RetryAfter = time.Duration((1 - tokens) * float64(perToken))
+	}
+
+	return result
+}
+
+// refill returns the number of tokens in a bucket that held tokens elapsed
+// ago.
+func refill(tokens float64, elapsed time.Duration, rate config.Rate) float64 {
+	tokens += elapsed.Seconds() * float64(rate.Limit) / rate.Period.Seconds()
+	return min(tokens, float64(rate.Limit))
+}
@@ -2,6 +2,8 @@ package repositories
 
 import (
 	"context"
+	"regexp"
+	"time"
 
 	"github.com/enxg/skyticket/internal/models"
 	"go.mongodb.org/mongo-driver/v2/bson"
@@ -14,6 +16,33 @@ type EventRepository interface {
 	Find(ctx context.Context, filter models.Event) ([]models.Event, error)
 	Update(ctx context.Context, event models.Event) (models.Event, error)
 	Delete(ctx context.Context, id bson.ObjectID) error
+	List(ctx context.Context, query EventQuery) ([]models.Event, error)
+	TransitionStatus(ctx context.Context, id bson.ObjectID, from []models.EventStatus, to models.EventStatus) (bool, error)
+	CompletePastEvents(ctx context.Context, now time.Time) (int64, error)
+	CreateMany(ctx context.Context, events []models.Event) ([]models.Event, error)
+	UpdateSeriesEvents(ctx context.Context, seriesID bson.ObjectID, after time.Time, event models.Event) (int64, error)
+	Search(ctx context.Context, query EventSearchQuery) (models.EventSearchResult, error)
+	SalesSummary(ctx context.Context, from time.Time, to time.Time) ([]models.EventSalesSummary, error)
+}
+
+type EventQuery struct {
+	Status        models.EventStatus
+	ExcludeStatus models.EventStatus
+	LocalDay      string
+	Category      string
+	Tag           string
+	Near          *models.GeoPoint
+	RadiusKm      float64
+	Before        time.Time
+}
+
+type EventSearchQuery struct {
+	Text          string
+	ExcludeStatus models.EventStatus
+	Category      string
+	Venue         string
+	Month         string
+	Limit         int
 }
 
 type eventRepository struct {
@@ -27,6 +56,9 @@ func NewEventRepository(db *mongo.Database) EventRepository {
 }
 
 func (e *eventRepository) Create(ctx context.Context, event models.Event) (models.Event, error) {
+	ctx, done := observe(ctx, "event", "Create")
+	defer done()
+
 	res, err := e.collection.InsertOne(ctx, event)
 	if err != nil {
 		return models.Event{}, err
@@ -37,6 +69,9 @@ func (e *eventRepository) Create(ctx context.Context, event models.Event) (model
 }
 
 func (e *eventRepository) FindOneByID(ctx context.Context, id bson.ObjectID) (models.Event, error) {
+	ctx, done := observe(ctx, "event", "FindOneByID")
+	defer done()
+
 	var result models.Event
 	err := e.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&result)
 	if err != nil {
@@ -47,6 +82,9 @@ func (e *eventRepository) FindOneByID(ctx context.Context, id bson.ObjectID) (mo
 }
 
 func (e *eventRepository) Find(ctx context.Context, filter models.Event) ([]models.Event, error) {
+	ctx, done := observe(ctx, "event", "Find")
+	defer done()
+
 	events := make([]models.Event, 0)
 
 	cursor, err := e.collection.Find(ctx, filter)
@@ -61,6 +99,9 @@ func (e *eventRepository) Find(ctx context.Context, filter models.Event) ([]mode
 }
 
 func (e *eventRepository) Update(ctx context.Context, event models.Event) (models.Event, error) {
+	ctx, done := observe(ctx, "event", "Update")
+	defer done()
+
 	filter := bson.M{"_id": event.ID}
 	update := bson.M{"$set": event}
 
@@ -77,6 +118,9 @@ func (e *eventRepository) Update(ctx context.Context, event models.Event) (model
 }
 
 func (e *eventRepository) Delete(ctx context.Context, id bson.ObjectID) error {
+	ctx, done := observe(ctx, "event", "Delete")
+	defer done()
+
 	res, err := e.collection.DeleteOne(ctx, bson.M{"_id": id})
 	if err != nil {
 		return err
@@ -88,3 +132,276 @@ func (e *eventRepository) Delete(ctx context.Context, id bson.ObjectID) error {
 
 	return nil
 }
+
+func (e *eventRepository) List(ctx context.Context, query EventQuery) ([]models.Event, error) {
+	ctx, done := observe(ctx, "event", "List")
+	defer done()
+
+	events := make([]models.Event, 0)
+
+	filter := bson.M{}
+	status := bson.M{}
+	if query.Status == models.EventStatusOnSale {
+		// Events without a status are on sale.
+		status["$in"] = bson.A{models.EventStatusOnSale, nil}
+	} else if query.Status != "" {
+		status["$eq"] = query.Status
+	}
+	if query.ExcludeStatus != "" {
+		status["$ne"] = query.ExcludeStatus
+	}
+	if len(status) > 0 {
+		filter["status"] = status
+	}
+	if query.LocalDay != "" {
+		filter["local_date"] = bson.M{"$regex": "^" + regexp.QuoteMeta(query.LocalDay) + "T"}
+	}
+	if query.Category != "" {
+		filter["category"] = query.Category
+	}
+	if query.Tag != "" {
+		filter["tags"] = query.Tag
+	}
+	if !query.Before.IsZero() {
+		filter["date"] = bson.M{"$lt": query.Before}
+	}
+
+	var cursor *mongo.Cursor
+	var err error
+	if query.Near != nil {
+		cursor, err = e.collection.Aggregate(ctx, mongo.Pipeline{
+			{{Key: "$geoNear", Value: bson.M{
+				"near":               query.Near,
+				"key":                "location",
+				"distanceField":      "distance_km",
+				"distanceMultiplier": 0.001,
+				"maxDistance":        query.RadiusKm * 1000,
+				"spherical":          true,
+				"query":              filter,
+			}}},
+		})
+	} else {
+		cursor, err = e.collection.Find(ctx, filter)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (e *eventRepository) TransitionStatus(ctx context.Context, id bson.ObjectID, from []models.EventStatus, to models.EventStatus) (bool, error) {
+	ctx, done := observe(ctx, "event", "TransitionStatus")
+	defer done()
+
+	// Events without a status count as on sale, see Event.EffectiveStatus.
+	statuses := bson.A{}
+	for _, status := range from {
+		statuses = append(statuses, status)
+		if status == models.EventStatusOnSale {
+			statuses = append(statuses, nil)
+		}
+	}
+
+	filter := bson.M{
+		"_id":    id,
+		"status": bson.M{"$in": statuses},
+	}
+	update := bson.M{"$set": bson.M{"status": to}}
+
+	res, err := e.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return res.ModifiedCount > 0, nil
+}
+
+func (e *eventRepository) CompletePastEvents(ctx context.Context, now time.Time) (int64, error) {
+	ctx, done := observe(ctx, "event", "CompletePastEvents")
+	defer done()
+
+	filter := bson.M{
+		"date": bson.M{"$lt": now},
+		"status": bson.M{"$in": bson.A{
+			models.EventStatusPublished,
+			models.EventStatusOnSale,
+			models.EventStatusSoldOut,
+		}},
+	}
+	update := bson.M{"$set": bson.M{"status": models.EventStatusCompleted}}
+
+	res, err := e.collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.ModifiedCount, nil
+}
+
+func (e *eventRepository) CreateMany(ctx context.Context, events []models.Event) ([]models.Event, error) {
+	ctx, done := observe(ctx, "event", "CreateMany")
+	defer done()
+
+	res, err := e.collection.InsertMany(ctx, events)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, id := range res.InsertedIDs {
+		events[i].ID = id.(bson.ObjectID)
+	}
+	return events, nil
+}
+
+func (e *eventRepository) UpdateSeriesEvents(ctx context.Context, seriesID bson.ObjectID, after time.Time, event models.Event) (int64, error) {
+	ctx, done := observe(ctx, "event", "UpdateSeriesEvents")
+	defer done()
+
+	filter := bson.M{
+		"series_id": seriesID,
+		"date":      bson.M{"$gt": after},
+		"status":    bson.M{"$nin": bson.A{models.EventStatusCancelled, models.EventStatusCompleted}},
+	}
+	update := bson.M{"$set": event}
+
+	res, err := e.collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.ModifiedCount, nil
+}
+
+func (e *eventRepository) Search(ctx context.Context, query EventSearchQuery) (models.EventSearchResult, error) {
+	ctx, done := observe(ctx, "event", "Search")
+	defer done()
+
+	match := bson.M{
+		"$text": bson.M{"$search": query.Text},
+	}
+	if query.ExcludeStatus != "" {
+		match["status"] = bson.M{"$ne": query.ExcludeStatus}
+	}
+	if query.Category != "" {
+		match["category"] = query.Category
+	}
+	if query.Venue != "" {
+		match["venue"] = query.Venue
+	}
+	if query.Month != "" {
+		match["local_date"] = bson.M{"$regex": "^" + regexp.QuoteMeta(query.Month) + "-"}
+	}
+
+	facet := func(field any) bson.A {
+		return bson.A{
+			bson.M{"$group": bson.M{"_id": field, "count": bson.M{"$sum": 1}}},
+			bson.M{"$match": bson.M{"_id": bson.M{"$nin": bson.A{nil, ""}}}},
+			bson.M{"$sort": bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}},
+		}
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: match}},
+		{{Key: "$addFields", Value: bson.M{"score": bson.M{"$meta": "textScore"}}}},
+		{{Key: "$facet", Value: bson.M{
+			"results": bson.A{
+				bson.M{"$sort": bson.D{{Key: "score", Value: -1}, {Key: "date", Value: 1}}},
+				bson.M{"$limit": query.Limit},
+			},
+			"categories": facet("$category"),
+			"venues":     facet("$venue"),
+			"months":     facet(bson.M{"$substrBytes": bson.A{"$local_date", 0, 7}}),
+		}}},
+	}
+
+	cursor, err := e.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return models.EventSearchResult{}, err
+	}
+	defer cursor.Close(ctx)
+
+	var result models.EventSearchResult
+	if cursor.Next(ctx) {
+		if err := cursor.Decode(&result); err != nil {
+			return models.EventSearchResult{}, err
+		}
+	}
+
+	return result, cursor.Err()
+}
+
+func (e *eventRepository) SalesSummary(ctx context.Context, from time.Time, to time.Time) ([]models.EventSalesSummary, error) {
+	ctx, done := observe(ctx, "event", "SalesSummary")
+	defer done()
+
+	summaries := make([]models.EventSalesSummary, 0)
+
+	first := func(field string) bson.M {
+		return bson.M{"$ifNull": bson.A{bson.M{"$first": field}, 0}}
+	}
+
+	cursor, err := e.collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"date":   bson.M{"$gte": from, "$lt": to},
+			"status": bson.M{"$ne": models.EventStatusDraft},
+		}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "tickets",
+			"localField":   "_id",
+			"foreignField": "event_id",
+			"as":           "tickets",
+			"pipeline": bson.A{
+				bson.M{"$project": bson.M{"price": 1, "units": ticketUnits}},
+				bson.M{"$unwind": "$units"},
+				bson.M{"$group": bson.M{
+					"_id":   nil,
+					"total": bson.M{"$sum": "$units.count"},
+					"sold": bson.M{"$sum": bson.M{
+						"$cond": bson.A{bson.M{"$eq": bson.A{"$units.status", models.TicketStatusReserved}}, "$units.count", 0},
+					}},
+					"revenue": bson.M{"$sum": bson.M{
+						"$cond": bson.A{
+							bson.M{"$eq": bson.A{"$units.status", models.TicketStatusReserved}},
+							bson.M{"$multiply": bson.A{"$units.count", "$price"}},
+							0,
+						},
+					}},
+				}},
+			},
+		}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "reservations",
+			"localField":   "_id",
+			"foreignField": "event_id",
+			"as":           "cancellations",
+			"pipeline": bson.A{
+				bson.M{"$match": bson.M{"status": models.ReservationStatusCancelled}},
+				bson.M{"$count": "count"},
+			},
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":           0,
+			"event_id":      "$_id",
+			"name":          1,
+			"date":          1,
+			"status":        1,
+			"total_tickets": first("$tickets.total"),
+			"tickets_sold":  first("$tickets.sold"),
+			"gross_revenue": first("$tickets.revenue"),
+			"cancellations": first("$cancellations.count"),
+		}}},
+		{{Key: "$sort", Value: bson.M{"date": 1}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := cursor.All(ctx, &summaries); err != nil {
+		return nil, err
+	}
+
+	return summaries, nil
+}
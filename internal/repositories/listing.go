@@ -0,0 +1,127 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/enxg/skyticket/internal/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type ListingRepository interface {
+	Create(ctx context.Context, listing models.Listing) (models.Listing, error)
+	FindOne(ctx context.Context, filter models.Listing) (models.Listing, error)
+	Find(ctx context.Context, filter models.Listing) ([]models.Listing, error)
+	DeleteMany(ctx context.Context, filter models.Listing) error
+	AttemptToSell(ctx context.Context, id bson.ObjectID, buyerName string, at time.Time) (bool, error)
+	AttemptToCancel(ctx context.Context, id bson.ObjectID, sellerToken string) (bool, error)
+}
+
+type listingRepository struct {
+	collection *mongo.Collection
+}
+
+func NewListingRepository(db *mongo.Database) ListingRepository {
+	return &listingRepository{
+		collection: db.Collection("listings"),
+	}
+}
+
+func (l *listingRepository) Create(ctx context.Context, listing models.Listing) (models.Listing, error) {
+	ctx, done := observe(ctx, "listing", "Create")
+	defer done()
+
+	res, err := l.collection.InsertOne(ctx, listing)
+	if err != nil {
+		return models.Listing{}, err
+	}
+
+	listing.ID = res.InsertedID.(bson.ObjectID)
+	return listing, nil
+}
+
+func (l *listingRepository) FindOne(ctx context.Context, filter models.Listing) (models.Listing, error) {
+	ctx, done := observe(ctx, "listing", "FindOne")
+	defer done()
+
+	var result models.Listing
+	err := l.collection.FindOne(ctx, filter).Decode(&result)
+	if err != nil {
+		return models.Listing{}, err
+	}
+
+	return result, nil
+}
+
+func (l *listingRepository) Find(ctx context.Context, filter models.Listing) ([]models.Listing, error) {
+	ctx, done := observe(ctx, "listing", "Find")
+	defer done()
+
+	listings := make([]models.Listing, 0)
+
+	cursor, err := l.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if err := cursor.All(ctx, &listings); err != nil {
+		return nil, err
+	}
+
+	return listings, nil
+}
+
+func (l *listingRepository) DeleteMany(ctx context.Context, filter models.Listing) error {
+	ctx, done := observe(ctx, "listing", "DeleteMany")
+	defer done()
+
+	_, err := l.collection.DeleteMany(ctx, filter)
+	return err
+}
+
+func (l *listingRepository) AttemptToSell(ctx context.Context, id bson.ObjectID, buyerName string, at time.Time) (bool, error) {
+	ctx, done := observe(ctx, "listing", "AttemptToSell")
+	defer done()
+
+	filter := bson.M{
+		"_id":    id,
+		"status": models.ListingStatusActive,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status":     models.ListingStatusSold,
+			"buyer_name": buyerName,
+			"sold_at":    at,
+		},
+	}
+
+	res, err := l.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return res.ModifiedCount > 0, nil
+}
+
+func (l *listingRepository) AttemptToCancel(ctx context.Context, id bson.ObjectID, sellerToken string) (bool, error) {
+	ctx, done := observe(ctx, "listing", "AttemptToCancel")
+	defer done()
+
+	filter := bson.M{
+		"_id":          id,
+		"seller_token": sellerToken,
+		"status":       models.ListingStatusActive,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status": models.ListingStatusCancelled,
+		},
+	}
+
+	res, err := l.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return res.ModifiedCount > 0, nil
+}
@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"context"
+	"strings"
+
+	"github.com/enxg/skyticket/internal/metrics"
+	"github.com/enxg/skyticket/internal/tracing"
+)
+
+// observe starts a span and a latency measurement for a repository method.
+// The returned context carries the span and should be used for the queries
+// of the method; the returned function ends both and is meant to be
+// deferred.
+func observe(ctx context.Context, repository string, method string) (context.Context, func()) {
+	ctx, span := tracing.Start(ctx, strings.ToUpper(repository[:1])+repository[1:]+"Repository."+method)
+	stop := metrics.ObserveQuery(repository, method)
+
+	return ctx, func() {
+		stop()
+		span.End()
+	}
+}
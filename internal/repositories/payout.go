@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/enxg/skyticket/internal/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type PayoutRepository interface {
+	Create(ctx context.Context, payout models.Payout) (models.Payout, error)
+	Find(ctx context.Context, filter models.Payout) ([]models.Payout, error)
+}
+
+type payoutRepository struct {
+	collection *mongo.Collection
+}
+
+func NewPayoutRepository(db *mongo.Database) PayoutRepository {
+	return &payoutRepository{
+		collection: db.Collection("payouts"),
+	}
+}
+
+func (p *payoutRepository) Create(ctx context.Context, payout models.Payout) (models.Payout, error) {
+	ctx, done := observe(ctx, "payout", "Create")
+	defer done()
+
+	res, err := p.collection.InsertOne(ctx, payout)
+	if err != nil {
+		return models.Payout{}, err
+	}
+
+	payout.ID = res.InsertedID.(bson.ObjectID)
+	return payout, nil
+}
+
+func (p *payoutRepository) Find(ctx context.Context, filter models.Payout) ([]models.Payout, error) {
+	ctx, done := observe(ctx, "payout", "Find")
+	defer done()
+
+	payouts := make([]models.Payout, 0)
+
+	cursor, err := p.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if err := cursor.All(ctx, &payouts); err != nil {
+		return nil, err
+	}
+
+	return payouts, nil
+}
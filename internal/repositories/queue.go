@@ -0,0 +1,220 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/enxg/skyticket/internal/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+type QueueRepository interface {
+	Configure(ctx context.Context, eventID bson.ObjectID, enabled bool, batchSize int, intervalSeconds int) (models.EventQueue, error)
+	FindByEventID(ctx context.Context, eventID bson.ObjectID) (models.EventQueue, error)
+	NextPosition(ctx context.Context, eventID bson.ObjectID) (int64, error)
+	CreateEntry(ctx context.Context, entry models.QueueEntry) (models.QueueEntry, error)
+	FindEntry(ctx context.Context, eventID bson.ObjectID, id bson.ObjectID) (models.QueueEntry, error)
+	CountWaiting(ctx context.Context, eventID bson.ObjectID) (int64, error)
+	AdmitDue(ctx context.Context, now time.Time, admissionTTL time.Duration) (int64, error)
+	AdmitEntry(ctx context.Context, id bson.ObjectID, now time.Time, admissionTTL time.Duration) (models.QueueEntry, error)
+}
+
+type queueRepository struct {
+	queues  *mongo.Collection
+	entries *mongo.Collection
+}
+
+func NewQueueRepository(db *mongo.Database) QueueRepository {
+	return &queueRepository{
+		queues:  db.Collection("queues"),
+		entries: db.Collection("queue_entries"),
+	}
+}
+
+// Configure creates or updates the queue of an event. Positions and
+// admissions are kept when an existing queue is reconfigured.
+func (q *queueRepository) Configure(ctx context.Context, eventID bson.ObjectID, enabled bool, batchSize int, intervalSeconds int) (models.EventQueue, error) {
+	ctx, done := observe(ctx, "queue", "Configure")
+	defer done()
+
+	update := bson.M{
+		"$set": bson.M{
+			"enabled":          enabled,
+			"batch_size":       batchSize,
+			"interval_seconds": intervalSeconds,
+		},
+		"$setOnInsert": bson.M{
+			"last_position":     int64(0),
+			"admitted_up_to":    int64(0),
+			"next_admission_at": time.Now(),
+		},
+	}
+	opts := options.FindOneAndUpdate().
+		SetUpsert(true).
+		SetReturnDocument(options.After)
+
+	var result models.EventQueue
+	err := q.queues.FindOneAndUpdate(ctx, bson.M{"_id": eventID}, update, opts).Decode(&result)
+	if err != nil {
+		return models.EventQueue{}, err
+	}
+
+	return result, nil
+}
+
+func (q *queueRepository) FindByEventID(ctx context.Context, eventID bson.ObjectID) (models.EventQueue, error) {
+	ctx, done := observe(ctx, "queue", "FindByEventID")
+	defer done()
+
+	var result models.EventQueue
+	err := q.queues.FindOne(ctx, bson.M{"_id": eventID}).Decode(&result)
+	if err != nil {
+		return models.EventQueue{}, err
+	}
+
+	return result, nil
+}
+
+// NextPosition hands out the next position in an enabled queue. It returns
+// mongo.ErrNoDocuments when the event has no enabled queue.
+func (q *queueRepository) NextPosition(ctx context.Context, eventID bson.ObjectID) (int64, error) {
+	ctx, done := observe(ctx, "queue", "NextPosition")
+	defer done()
+
+	filter := bson.M{
+		"_id":     eventID,
+		"enabled": true,
+	}
+	update := bson.M{
+		"$inc": bson.M{"last_position": 1},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var result models.EventQueue
+	err := q.queues.FindOneAndUpdate(ctx, filter, update, opts).Decode(&result)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.LastPosition, nil
+}
+
+func (q *queueRepository) CreateEntry(ctx context.Context, entry models.QueueEntry) (models.QueueEntry, error) {
+	ctx, done := observe(ctx, "queue", "CreateEntry")
+	defer done()
+
+	res, err := q.entries.InsertOne(ctx, entry)
+	if err != nil {
+		return models.QueueEntry{}, err
+	}
+
+	entry.ID = res.InsertedID.(bson.ObjectID)
+	return entry, nil
+}
+
+func (q *queueRepository) FindEntry(ctx context.Context, eventID bson.ObjectID, id bson.ObjectID) (models.QueueEntry, error) {
+	ctx, done := observe(ctx, "queue", "FindEntry")
+	defer done()
+
+	var result models.QueueEntry
+	err := q.entries.FindOne(ctx, bson.M{"_id": id, "event_id": eventID}).Decode(&result)
+	if err != nil {
+		return models.QueueEntry{}, err
+	}
+
+	return result, nil
+}
+
+func (q *queueRepository) CountWaiting(ctx context.Context, eventID bson.ObjectID) (int64, error) {
+	ctx, done := observe(ctx, "queue", "CountWaiting")
+	defer done()
+
+	return q.entries.CountDocuments(ctx, bson.M{
+		"event_id": eventID,
+		"status":   models.QueueEntryStatusWaiting,
+	})
+}
+
+// AdmitDue admits the next batch of every enabled queue whose interval has
+// passed and returns the number of entries admitted. Claiming a batch is a
+// conditional update on the queue, so running this on several instances at
+// once admits each batch only once.
+func (q *queueRepository) AdmitDue(ctx context.Context, now time.Time, admissionTTL time.Duration) (int64, error) {
+	ctx, done := observe(ctx, "queue", "AdmitDue")
+	defer done()
+
+	var admitted int64
+	for {
+		filter := bson.M{
+			"enabled":           true,
+			"next_admission_at": bson.M{"$lte": now},
+			"$expr":             bson.M{"$lt": bson.A{"$admitted_up_to", "$last_position"}},
+		}
+		update := bson.A{
+			bson.M{"$set": bson.M{
+				"admitted_up_to":    bson.M{"$min": bson.A{"$last_position", bson.M{"$add": bson.A{"$admitted_up_to", "$batch_size"}}}},
+				"next_admission_at": bson.M{"$add": bson.A{now, bson.M{"$multiply": bson.A{"$interval_seconds", 1000}}}},
+			}},
+		}
+
+		var before models.EventQueue
+		err := q.queues.FindOneAndUpdate(ctx, filter, update).Decode(&before)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return admitted, nil
+		}
+		if err != nil {
+			return admitted, err
+		}
+
+		res, err := q.entries.UpdateMany(ctx, bson.M{
+			"event_id": before.EventID,
+			"status":   models.QueueEntryStatusWaiting,
+			"position": bson.M{
+				"$gt":  before.AdmittedUpTo,
+				"$lte": before.AdmittedUpTo + int64(before.BatchSize),
+			},
+		}, bson.M{
+			"$set": bson.M{
+				"status":      models.QueueEntryStatusAdmitted,
+				"admitted_at": now,
+				"expires_at":  now.Add(admissionTTL),
+			},
+		})
+		if err != nil {
+			return admitted, err
+		}
+
+		admitted += res.ModifiedCount
+	}
+}
+
+// AdmitEntry admits a single waiting entry. It covers entries that were
+// inserted only after the batch containing their position was admitted.
+func (q *queueRepository) AdmitEntry(ctx context.Context, id bson.ObjectID, now time.Time, admissionTTL time.Duration) (models.QueueEntry, error) {
+	ctx, done := observe(ctx, "queue", "AdmitEntry")
+	defer done()
+
+	filter := bson.M{
+		"_id":    id,
+		"status": models.QueueEntryStatusWaiting,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status":      models.QueueEntryStatusAdmitted,
+			"admitted_at": now,
+			"expires_at":  now.Add(admissionTTL),
+		},
+	}
+	opts := options.FindOneAndUpdate().SetReturnDocument(options.After)
+
+	var result models.QueueEntry
+	err := q.entries.FindOneAndUpdate(ctx, filter, update, opts).Decode(&result)
+	if err != nil {
+		return models.QueueEntry{}, err
+	}
+
+	return result, nil
+}
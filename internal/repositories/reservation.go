@@ -2,24 +2,52 @@ package repositories
 
 import (
 	"context"
+	"errors"
+	"iter"
+	"time"
 
 	"github.com/enxg/skyticket/internal/models"
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
 )
 
 type ReservationRepository interface {
 	Create(ctx context.Context, reservation models.Reservation) (models.Reservation, error)
+	CreateMany(ctx context.Context, reservations []models.Reservation) ([]models.Reservation, error)
 	FindOne(ctx context.Context, filter models.Reservation) (models.Reservation, error)
-	Update(ctx context.Context, reservation models.Reservation) (models.Reservation, error)
-	Delete(ctx context.Context, filter models.Reservation) error
+	Find(ctx context.Context, filter models.Reservation) ([]models.Reservation, error)
+	FindCurrent(ctx context.Context, eventID bson.ObjectID, ticketID bson.ObjectID, token string) (models.Reservation, error)
+	Cancel(ctx context.Context, eventID bson.ObjectID, ticketID bson.ObjectID, token string, at time.Time) error
+	Expire(ctx context.Context, eventIDs []bson.ObjectID) (int64, error)
 	DeleteMany(ctx context.Context, filter models.Reservation) error
+	Count(ctx context.Context, filter models.Reservation) (int64, error)
+	CountPlaces(ctx context.Context, filter models.Reservation) (int64, error)
+	DailyStats(ctx context.Context, eventID bson.ObjectID, timezone string) ([]models.DailyReservations, error)
+	StreamManifest(ctx context.Context, eventID bson.ObjectID, sortBy ManifestSort) (iter.Seq2[models.ManifestEntry, error], error)
+	AttemptToCheckIn(ctx context.Context, eventID bson.ObjectID, token string, at time.Time) (ReservationCheckInAttemptResult, error)
+	SetPendingTransfer(ctx context.Context, eventID bson.ObjectID, ticketID bson.ObjectID, token string, transfer models.PendingTransfer) (bool, error)
+	AttemptToTransfer(ctx context.Context, eventID bson.ObjectID, ticketID bson.ObjectID, code string, newToken string, at time.Time) (models.Reservation, error)
+	Reassign(ctx context.Context, id bson.ObjectID, token string, newToken string, record models.TransferRecord) (models.Reservation, error)
 }
 
 type reservationRepository struct {
 	collection *mongo.Collection
 }
 
+type ManifestSort string
+
+const (
+	ManifestSortSeat ManifestSort = "seat"
+	ManifestSortName ManifestSort = "name"
+)
+
+type ReservationCheckInAttemptResult struct {
+	ReservationFound bool
+	CheckedIn        bool
+	Reservation      models.Reservation
+}
+
 func NewReservationRepository(db *mongo.Database) ReservationRepository {
 	return &reservationRepository{
 		collection: db.Collection("reservations"),
@@ -27,6 +55,9 @@ func NewReservationRepository(db *mongo.Database) ReservationRepository {
 }
 
 func (r *reservationRepository) Create(ctx context.Context, reservation models.Reservation) (models.Reservation, error) {
+	ctx, done := observe(ctx, "reservation", "Create")
+	defer done()
+
 	res, err := r.collection.InsertOne(ctx, reservation)
 	if err != nil {
 		return models.Reservation{}, err
@@ -36,7 +67,25 @@ func (r *reservationRepository) Create(ctx context.Context, reservation models.R
 	return reservation, nil
 }
 
+func (r *reservationRepository) CreateMany(ctx context.Context, reservations []models.Reservation) ([]models.Reservation, error) {
+	ctx, done := observe(ctx, "reservation", "CreateMany")
+	defer done()
+
+	res, err := r.collection.InsertMany(ctx, reservations)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, id := range res.InsertedIDs {
+		reservations[i].ID = id.(bson.ObjectID)
+	}
+	return reservations, nil
+}
+
 func (r *reservationRepository) FindOne(ctx context.Context, filter models.Reservation) (models.Reservation, error) {
+	ctx, done := observe(ctx, "reservation", "FindOne")
+	defer done()
+
 	var result models.Reservation
 	err := r.collection.FindOne(ctx, filter).Decode(&result)
 	if err != nil {
@@ -46,41 +95,390 @@ func (r *reservationRepository) FindOne(ctx context.Context, filter models.Reser
 	return result, nil
 }
 
-func (r *reservationRepository) Update(ctx context.Context, reservation models.Reservation) (models.Reservation, error) {
+func (r *reservationRepository) Find(ctx context.Context, filter models.Reservation) ([]models.Reservation, error) {
+	ctx, done := observe(ctx, "reservation", "Find")
+	defer done()
+
+	reservations := make([]models.Reservation, 0)
+
+	cursor, err := r.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if err := cursor.All(ctx, &reservations); err != nil {
+		return nil, err
+	}
+
+	return reservations, nil
+}
+
+// FindCurrent returns the reservation of a ticket that has not been
+// cancelled. General admission tickets have many, so a token selects one; an
+// empty token matches any.
+func (r *reservationRepository) FindCurrent(ctx context.Context, eventID bson.ObjectID, ticketID bson.ObjectID, token string) (models.Reservation, error) {
+	ctx, done := observe(ctx, "reservation", "FindCurrent")
+	defer done()
+
 	filter := bson.M{
-		"ticket_id": reservation.TicketID,
-		"event_id":  reservation.EventID,
+		"event_id":  eventID,
+		"ticket_id": ticketID,
+		"status":    bson.M{"$ne": models.ReservationStatusCancelled},
+	}
+	if token != "" {
+		filter["token"] = token
 	}
-	update := bson.M{"$set": reservation}
 
-	res, err := r.collection.UpdateOne(ctx, filter, update)
+	var result models.Reservation
+	err := r.collection.FindOne(ctx, filter).Decode(&result)
 	if err != nil {
 		return models.Reservation{}, err
 	}
 
-	if res.MatchedCount == 0 {
-		return models.Reservation{}, mongo.ErrNoDocuments
-	}
-
-	return r.FindOne(ctx, models.Reservation{
-		TicketID: reservation.TicketID,
-	})
+	return result, nil
 }
 
-func (r *reservationRepository) Delete(ctx context.Context, filter models.Reservation) error {
-	res, err := r.collection.DeleteOne(ctx, filter)
+func (r *reservationRepository) Cancel(ctx context.Context, eventID bson.ObjectID, ticketID bson.ObjectID, token string, at time.Time) error {
+	ctx, done := observe(ctx, "reservation", "Cancel")
+	defer done()
+
+	filter := bson.M{
+		"event_id":  eventID,
+		"ticket_id": ticketID,
+		"status":    models.ReservationStatusActive,
+	}
+	if token != "" {
+		filter["token"] = token
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status":       models.ReservationStatusCancelled,
+			"cancelled_at": at,
+		},
+		"$unset": bson.M{"pending_transfer": ""},
+	}
+
+	res, err := r.collection.UpdateOne(ctx, filter, update)
 	if err != nil {
 		return err
 	}
 
-	if res.DeletedCount == 0 {
+	if res.MatchedCount == 0 {
 		return mongo.ErrNoDocuments
 	}
 
 	return nil
 }
 
+func (r *reservationRepository) Expire(ctx context.Context, eventIDs []bson.ObjectID) (int64, error) {
+	ctx, done := observe(ctx, "reservation", "Expire")
+	defer done()
+
+	filter := bson.M{
+		"event_id": bson.M{"$in": eventIDs},
+		"status":   models.ReservationStatusActive,
+	}
+	update := bson.M{
+		"$set":   bson.M{"status": models.ReservationStatusExpired},
+		"$unset": bson.M{"pending_transfer": ""},
+	}
+
+	res, err := r.collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.ModifiedCount, nil
+}
+
 func (r *reservationRepository) DeleteMany(ctx context.Context, filter models.Reservation) error {
+	ctx, done := observe(ctx, "reservation", "DeleteMany")
+	defer done()
+
 	_, err := r.collection.DeleteMany(ctx, filter)
 	return err
 }
+
+func (r *reservationRepository) Count(ctx context.Context, filter models.Reservation) (int64, error) {
+	ctx, done := observe(ctx, "reservation", "Count")
+	defer done()
+
+	return r.collection.CountDocuments(ctx, filter)
+}
+
+// CountPlaces counts the people admitted by the matching reservations, which
+// is their quantity for general admission and one otherwise.
+func (r *reservationRepository) CountPlaces(ctx context.Context, filter models.Reservation) (int64, error) {
+	ctx, done := observe(ctx, "reservation", "CountPlaces")
+	defer done()
+
+	cursor, err := r.collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: filter}},
+		{{Key: "$group", Value: bson.M{
+			"_id": nil,
+			"places": bson.M{"$sum": bson.M{
+				"$max": bson.A{bson.M{"$ifNull": bson.A{"$quantity", 1}}, 1},
+			}},
+		}}},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var result []struct {
+		Places int64 `bson:"places"`
+	}
+	if err := cursor.All(ctx, &result); err != nil {
+		return 0, err
+	}
+	if len(result) == 0 {
+		return 0, nil
+	}
+
+	return result[0].Places, nil
+}
+
+func (r *reservationRepository) DailyStats(ctx context.Context, eventID bson.ObjectID, timezone string) ([]models.DailyReservations, error) {
+	ctx, done := observe(ctx, "reservation", "DailyStats")
+	defer done()
+
+	stats := make([]models.DailyReservations, 0)
+
+	day := func(field string) bson.M {
+		return bson.M{"$dateToString": bson.M{
+			"format":   "%Y-%m-%d",
+			"date":     field,
+			"timezone": timezone,
+		}}
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"event_id": eventID}}},
+		{{Key: "$project", Value: bson.M{
+			"entries": bson.M{"$concatArrays": bson.A{
+				bson.A{bson.M{"day": day("$reservation_date"), "reservations": 1, "cancellations": 0}},
+				bson.M{"$cond": bson.A{
+					bson.M{"$ifNull": bson.A{"$cancelled_at", false}},
+					bson.A{bson.M{"day": day("$cancelled_at"), "reservations": 0, "cancellations": 1}},
+					bson.A{},
+				}},
+			}},
+		}}},
+		{{Key: "$unwind", Value: "$entries"}},
+		{{Key: "$group", Value: bson.M{
+			"_id":           "$entries.day",
+			"reservations":  bson.M{"$sum": "$entries.reservations"},
+			"cancellations": bson.M{"$sum": "$entries.cancellations"},
+		}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := cursor.All(ctx, &stats); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// StreamManifest runs the manifest query up front so that query errors are
+// reported before anything is written, then yields entries straight from the
+// cursor. The cursor is closed once the sequence is exhausted or abandoned.
+func (r *reservationRepository) StreamManifest(ctx context.Context, eventID bson.ObjectID, sortBy ManifestSort) (iter.Seq2[models.ManifestEntry, error], error) {
+	ctx, done := observe(ctx, "reservation", "StreamManifest")
+	defer done()
+
+	sort := bson.D{{Key: "seat_number", Value: 1}}
+	if sortBy == ManifestSortName {
+		sort = bson.D{{Key: "customer_name", Value: 1}, {Key: "seat_number", Value: 1}}
+	}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"event_id": eventID,
+			"status":   bson.M{"$ne": models.ReservationStatusCancelled},
+		}}},
+		{{Key: "$lookup", Value: bson.M{
+			"from":         "tickets",
+			"localField":   "ticket_id",
+			"foreignField": "_id",
+			"as":           "ticket",
+		}}},
+		{{Key: "$project", Value: bson.M{
+			"_id":              0,
+			"seat_number":      bson.M{"$first": "$ticket.seat_number"},
+			"category":         bson.M{"$first": "$ticket.category"},
+			"ticket_type":      bson.M{"$ifNull": bson.A{bson.M{"$first": "$ticket.type"}, models.TicketTypeSeated}},
+			"places":           bson.M{"$max": bson.A{bson.M{"$ifNull": bson.A{"$quantity", 1}}, 1}},
+			"customer_name":    1,
+			"reservation_date": 1,
+			"status":           1,
+		}}},
+		{{Key: "$sort", Value: sort}},
+	}
+
+	opts := options.Aggregate().SetCollation(&options.Collation{
+		Locale:          "en",
+		NumericOrdering: true,
+	})
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(yield func(models.ManifestEntry, error) bool) {
+		defer cursor.Close(ctx)
+
+		for cursor.Next(ctx) {
+			var entry models.ManifestEntry
+			err := cursor.Decode(&entry)
+			if !yield(entry, err) || err != nil {
+				return
+			}
+		}
+
+		if err := cursor.Err(); err != nil {
+			yield(models.ManifestEntry{}, err)
+		}
+	}, nil
+}
+
+func (r *reservationRepository) AttemptToCheckIn(ctx context.Context, eventID bson.ObjectID, token string, at time.Time) (ReservationCheckInAttemptResult, error) {
+	ctx, done := observe(ctx, "reservation", "AttemptToCheckIn")
+	defer done()
+
+	filter := bson.M{
+		"event_id": eventID,
+		"token":    token,
+		"status":   models.ReservationStatusActive,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status":        models.ReservationStatusCheckedIn,
+			"checked_in_at": at,
+		},
+	}
+
+	var result models.Reservation
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&result)
+	if err == nil {
+		return ReservationCheckInAttemptResult{
+			ReservationFound: true,
+			CheckedIn:        true,
+			Reservation:      result,
+		}, nil
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return ReservationCheckInAttemptResult{}, err
+	}
+
+	result, err = r.FindOne(ctx, models.Reservation{
+		EventID: eventID,
+		Token:   token,
+	})
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return ReservationCheckInAttemptResult{}, nil
+		}
+		return ReservationCheckInAttemptResult{}, err
+	}
+
+	return ReservationCheckInAttemptResult{
+		ReservationFound: true,
+		Reservation:      result,
+	}, nil
+}
+
+func (r *reservationRepository) SetPendingTransfer(ctx context.Context, eventID bson.ObjectID, ticketID bson.ObjectID, token string, transfer models.PendingTransfer) (bool, error) {
+	ctx, done := observe(ctx, "reservation", "SetPendingTransfer")
+	defer done()
+
+	filter := bson.M{
+		"event_id":  eventID,
+		"ticket_id": ticketID,
+		"token":     token,
+		"status":    models.ReservationStatusActive,
+	}
+	update := bson.M{"$set": bson.M{"pending_transfer": transfer}}
+
+	res, err := r.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return res.MatchedCount > 0, nil
+}
+
+func (r *reservationRepository) AttemptToTransfer(ctx context.Context, eventID bson.ObjectID, ticketID bson.ObjectID, code string, newToken string, at time.Time) (models.Reservation, error) {
+	ctx, done := observe(ctx, "reservation", "AttemptToTransfer")
+	defer done()
+
+	filter := bson.M{
+		"event_id":                    eventID,
+		"ticket_id":                   ticketID,
+		"status":                      models.ReservationStatusActive,
+		"pending_transfer.code":       code,
+		"pending_transfer.expires_at": bson.M{"$gt": at},
+	}
+	update := []bson.M{
+		{
+			"$set": bson.M{
+				"transfers": bson.M{
+					"$concatArrays": bson.A{
+						bson.M{"$ifNull": bson.A{"$transfers", bson.A{}}},
+						bson.A{
+							bson.M{
+								"from_customer_name": "$customer_name",
+								"to_customer_name":   "$pending_transfer.recipient_name",
+								"transferred_at":     at,
+								"method":             models.TransferMethodTransfer,
+							},
+						},
+					},
+				},
+				"customer_name": "$pending_transfer.recipient_name",
+				"token":         newToken,
+			},
+		},
+		{
+			"$unset": "pending_transfer",
+		},
+	}
+
+	var result models.Reservation
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&result)
+	if err != nil {
+		return models.Reservation{}, err
+	}
+
+	return result, nil
+}
+
+func (r *reservationRepository) Reassign(ctx context.Context, id bson.ObjectID, token string, newToken string, record models.TransferRecord) (models.Reservation, error) {
+	ctx, done := observe(ctx, "reservation", "Reassign")
+	defer done()
+
+	filter := bson.M{
+		"_id":           id,
+		"token":         token,
+		"customer_name": record.FromCustomerName,
+		"status":        models.ReservationStatusActive,
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"customer_name": record.ToCustomerName,
+			"token":         newToken,
+		},
+		"$push":  bson.M{"transfers": record},
+		"$unset": bson.M{"pending_transfer": ""},
+	}
+
+	var result models.Reservation
+	err := r.collection.FindOneAndUpdate(ctx, filter, update, options.FindOneAndUpdate().SetReturnDocument(options.After)).Decode(&result)
+	if err != nil {
+		return models.Reservation{}, err
+	}
+
+	return result, nil
+}
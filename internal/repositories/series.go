@@ -0,0 +1,70 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/enxg/skyticket/internal/models"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type SeriesRepository interface {
+	Create(ctx context.Context, series models.EventSeries) (models.EventSeries, error)
+	FindOneByID(ctx context.Context, id bson.ObjectID) (models.EventSeries, error)
+	Update(ctx context.Context, series models.EventSeries) (models.EventSeries, error)
+}
+
+type seriesRepository struct {
+	collection *mongo.Collection
+}
+
+func NewSeriesRepository(db *mongo.Database) SeriesRepository {
+	return &seriesRepository{
+		collection: db.Collection("series"),
+	}
+}
+
+func (s *seriesRepository) Create(ctx context.Context, series models.EventSeries) (models.EventSeries, error) {
+	ctx, done := observe(ctx, "series", "Create")
+	defer done()
+
+	res, err := s.collection.InsertOne(ctx, series)
+	if err != nil {
+		return models.EventSeries{}, err
+	}
+
+	series.ID = res.InsertedID.(bson.ObjectID)
+	return series, nil
+}
+
+func (s *seriesRepository) FindOneByID(ctx context.Context, id bson.ObjectID) (models.EventSeries, error) {
+	ctx, done := observe(ctx, "series", "FindOneByID")
+	defer done()
+
+	var result models.EventSeries
+	err := s.collection.FindOne(ctx, bson.M{"_id": id}).Decode(&result)
+	if err != nil {
+		return models.EventSeries{}, err
+	}
+
+	return result, nil
+}
+
+func (s *seriesRepository) Update(ctx context.Context, series models.EventSeries) (models.EventSeries, error) {
+	ctx, done := observe(ctx, "series", "Update")
+	defer done()
+
+	filter := bson.M{"_id": series.ID}
+	update := bson.M{"$set": series}
+
+	res, err := s.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return models.EventSeries{}, err
+	}
+
+	if res.MatchedCount == 0 {
+		return models.EventSeries{}, mongo.ErrNoDocuments
+	}
+
+	return s.FindOneByID(ctx, series.ID)
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"errors"
 
 	"github.com/enxg/skyticket/internal/models"
 	"go.mongodb.org/mongo-driver/v2/bson"
@@ -10,12 +11,21 @@ import (
 
 type TicketRepository interface {
 	Create(ctx context.Context, ticket models.Ticket) (models.Ticket, error)
+	CreateMany(ctx context.Context, tickets []models.Ticket) ([]models.Ticket, error)
 	FindOne(ctx context.Context, filter models.Ticket) (models.Ticket, error)
 	Find(ctx context.Context, filter models.Ticket) ([]models.Ticket, error)
+	FindBySeatNumbers(ctx context.Context, eventID bson.ObjectID, seatNumbers []string) ([]models.Ticket, error)
 	Update(ctx context.Context, ticket models.Ticket) (models.Ticket, error)
 	Delete(ctx context.Context, filter models.Ticket) error
 	DeleteMany(ctx context.Context, filter models.Ticket) error
+	Count(ctx context.Context, filter models.Ticket) (int64, error)
+	StatusStats(ctx context.Context, eventID bson.ObjectID) ([]models.TicketStatusStats, error)
 	AttemptToReserve(ctx context.Context, eventID bson.ObjectID, ticketID bson.ObjectID) (TicketReservationAttemptResult, error)
+	FindAvailable(ctx context.Context, eventID bson.ObjectID, maxPrice int, section string) ([]models.Ticket, error)
+	ReserveMany(ctx context.Context, eventID bson.ObjectID, ticketIDs []bson.ObjectID) (int64, error)
+	AttemptToReserveCapacity(ctx context.Context, eventID bson.ObjectID, ticketID bson.ObjectID, quantity int) (TicketReservationAttemptResult, error)
+	ReleaseCapacity(ctx context.Context, eventID bson.ObjectID, ticketID bson.ObjectID, quantity int) error
+	ResizeCapacity(ctx context.Context, eventID bson.ObjectID, ticketID bson.ObjectID, capacity int) (bool, error)
 }
 
 type ticketRepository struct {
@@ -25,6 +35,8 @@ type ticketRepository struct {
 type TicketReservationAttemptResult struct {
 	TicketFound bool
 	Reserved    bool
+	Price       int
+	Ticket      models.Ticket
 }
 
 func NewTicketRepository(db *mongo.Database) TicketRepository {
@@ -34,6 +46,9 @@ func NewTicketRepository(db *mongo.Database) TicketRepository {
 }
 
 func (t *ticketRepository) Create(ctx context.Context, ticket models.Ticket) (models.Ticket, error) {
+	ctx, done := observe(ctx, "ticket", "Create")
+	defer done()
+
 	res, err := t.collection.InsertOne(ctx, ticket)
 	if err != nil {
 		return models.Ticket{}, err
@@ -43,7 +58,25 @@ func (t *ticketRepository) Create(ctx context.Context, ticket models.Ticket) (mo
 	return ticket, nil
 }
 
+func (t *ticketRepository) CreateMany(ctx context.Context, tickets []models.Ticket) ([]models.Ticket, error) {
+	ctx, done := observe(ctx, "ticket", "CreateMany")
+	defer done()
+
+	res, err := t.collection.InsertMany(ctx, tickets)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, id := range res.InsertedIDs {
+		tickets[i].ID = id.(bson.ObjectID)
+	}
+	return tickets, nil
+}
+
 func (t *ticketRepository) FindOne(ctx context.Context, filter models.Ticket) (models.Ticket, error) {
+	ctx, done := observe(ctx, "ticket", "FindOne")
+	defer done()
+
 	var result models.Ticket
 	err := t.collection.FindOne(ctx, filter).Decode(&result)
 	if err != nil {
@@ -54,6 +87,9 @@ func (t *ticketRepository) FindOne(ctx context.Context, filter models.Ticket) (m
 }
 
 func (t *ticketRepository) Find(ctx context.Context, filter models.Ticket) ([]models.Ticket, error) {
+	ctx, done := observe(ctx, "ticket", "Find")
+	defer done()
+
 	tickets := make([]models.Ticket, 0)
 
 	cursor, err := t.collection.Find(ctx, filter)
@@ -67,7 +103,32 @@ func (t *ticketRepository) Find(ctx context.Context, filter models.Ticket) ([]mo
 	return tickets, nil
 }
 
+func (t *ticketRepository) FindBySeatNumbers(ctx context.Context, eventID bson.ObjectID, seatNumbers []string) ([]models.Ticket, error) {
+	ctx, done := observe(ctx, "ticket", "FindBySeatNumbers")
+	defer done()
+
+	tickets := make([]models.Ticket, 0)
+
+	filter := bson.M{
+		"event_id":    eventID,
+		"seat_number": bson.M{"$in": seatNumbers},
+	}
+
+	cursor, err := t.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if err := cursor.All(ctx, &tickets); err != nil {
+		return nil, err
+	}
+
+	return tickets, nil
+}
+
 func (t *ticketRepository) Update(ctx context.Context, ticket models.Ticket) (models.Ticket, error) {
+	ctx, done := observe(ctx, "ticket", "Update")
+	defer done()
+
 	filter := bson.M{
 		"_id":      ticket.ID,
 		"event_id": ticket.EventID,
@@ -89,6 +150,9 @@ func (t *ticketRepository) Update(ctx context.Context, ticket models.Ticket) (mo
 }
 
 func (t *ticketRepository) Delete(ctx context.Context, filter models.Ticket) error {
+	ctx, done := observe(ctx, "ticket", "Delete")
+	defer done()
+
 	res, err := t.collection.DeleteOne(ctx, filter)
 	if err != nil {
 		return err
@@ -102,11 +166,64 @@ func (t *ticketRepository) Delete(ctx context.Context, filter models.Ticket) err
 }
 
 func (t *ticketRepository) DeleteMany(ctx context.Context, filter models.Ticket) error {
+	ctx, done := observe(ctx, "ticket", "DeleteMany")
+	defer done()
+
 	_, err := t.collection.DeleteMany(ctx, filter)
 	return err
 }
 
+func (t *ticketRepository) Count(ctx context.Context, filter models.Ticket) (int64, error) {
+	ctx, done := observe(ctx, "ticket", "Count")
+	defer done()
+
+	return t.collection.CountDocuments(ctx, filter)
+}
+
+// ticketUnits expands a ticket into the places it sells, each with a status
+// and a count. General admission tickets count once per place, split into the
+// remaining places and the sold ones; seated tickets are a single place.
+var ticketUnits = bson.M{"$cond": bson.M{
+	"if": bson.M{"$eq": bson.A{"$type", models.TicketTypeGeneralAdmission}},
+	"then": bson.A{
+		bson.M{"status": models.TicketStatusAvailable, "count": "$remaining"},
+		bson.M{"status": models.TicketStatusReserved, "count": bson.M{"$subtract": bson.A{"$capacity", "$remaining"}}},
+	},
+	"else": bson.A{
+		bson.M{"status": "$status", "count": 1},
+	},
+}}
+
+func (t *ticketRepository) StatusStats(ctx context.Context, eventID bson.ObjectID) ([]models.TicketStatusStats, error) {
+	ctx, done := observe(ctx, "ticket", "StatusStats")
+	defer done()
+
+	stats := make([]models.TicketStatusStats, 0)
+
+	cursor, err := t.collection.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"event_id": eventID}}},
+		{{Key: "$project", Value: bson.M{"price": 1, "units": ticketUnits}}},
+		{{Key: "$unwind", Value: "$units"}},
+		{{Key: "$group", Value: bson.M{
+			"_id":     "$units.status",
+			"count":   bson.M{"$sum": "$units.count"},
+			"revenue": bson.M{"$sum": bson.M{"$multiply": bson.A{"$units.count", "$price"}}},
+		}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := cursor.All(ctx, &stats); err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
 func (t *ticketRepository) AttemptToReserve(ctx context.Context, eventID bson.ObjectID, ticketID bson.ObjectID) (TicketReservationAttemptResult, error) {
+	ctx, done := observe(ctx, "ticket", "AttemptToReserve")
+	defer done()
+
 	filter := bson.M{
 		"_id":      ticketID,
 		"event_id": eventID,
@@ -125,13 +242,195 @@ func (t *ticketRepository) AttemptToReserve(ctx context.Context, eventID bson.Ob
 		},
 	}
 
-	res, err := t.collection.UpdateOne(ctx, filter, update)
+	var before models.Ticket
+	err := t.collection.FindOneAndUpdate(ctx, filter, update).Decode(&before)
 	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return TicketReservationAttemptResult{}, nil
+		}
 		return TicketReservationAttemptResult{}, err
 	}
 
 	return TicketReservationAttemptResult{
-		TicketFound: res.MatchedCount > 0,
-		Reserved:    res.ModifiedCount > 0,
+		TicketFound: true,
+		Reserved:    before.Status == models.TicketStatusAvailable,
+		Price:       before.Price,
+		Ticket:      before,
 	}, nil
 }
+
+// FindAvailable returns the available seated tickets of an event, optionally limited
+// to a price ceiling and a section. Tickets whose category matches the section
+// are included too. A zero maxPrice means no ceiling.
+func (t *ticketRepository) FindAvailable(ctx context.Context, eventID bson.ObjectID, maxPrice int, section string) ([]models.Ticket, error) {
+	ctx, done := observe(ctx, "ticket", "FindAvailable")
+	defer done()
+
+	tickets := make([]models.Ticket, 0)
+
+	filter := bson.M{
+		"event_id": eventID,
+		"status":   models.TicketStatusAvailable,
+		"type":     bson.M{"$ne": models.TicketTypeGeneralAdmission},
+	}
+	if maxPrice > 0 {
+		filter["price"] = bson.M{"$lte": maxPrice}
+	}
+	if section != "" {
+		filter["$or"] = bson.A{
+			bson.M{"section": section},
+			bson.M{"category": section},
+		}
+	}
+
+	cursor, err := t.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if err := cursor.All(ctx, &tickets); err != nil {
+		return nil, err
+	}
+
+	return tickets, nil
+}
+
+// ReserveMany marks the given tickets as reserved if they are still
+// available and returns how many were reserved. Callers reserving several
+// tickets together must run it in a transaction and abort when the count
+// falls short.
+func (t *ticketRepository) ReserveMany(ctx context.Context, eventID bson.ObjectID, ticketIDs []bson.ObjectID) (int64, error) {
+	ctx, done := observe(ctx, "ticket", "ReserveMany")
+	defer done()
+
+	filter := bson.M{
+		"_id":      bson.M{"$in": ticketIDs},
+		"event_id": eventID,
+		"status":   models.TicketStatusAvailable,
+	}
+	update := bson.M{
+		"$set": bson.M{"status": models.TicketStatusReserved},
+	}
+
+	res, err := t.collection.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.ModifiedCount, nil
+}
+
+// remainingStatus is an update pipeline expression for the status of a
+// general admission ticket once remaining has been set.
+var remainingStatus = bson.M{
+	"$cond": bson.M{
+		"if":   bson.M{"$gt": bson.A{"$remaining", 0}},
+		"then": models.TicketStatusAvailable,
+		"else": models.TicketStatusReserved,
+	},
+}
+
+// AttemptToReserveCapacity takes quantity places of a general admission
+// ticket. The remaining count is checked and decremented in a single update,
+// so concurrent reservations can never take more places than the capacity.
+// The ticket is marked as reserved once no places remain.
+func (t *ticketRepository) AttemptToReserveCapacity(ctx context.Context, eventID bson.ObjectID, ticketID bson.ObjectID, quantity int) (TicketReservationAttemptResult, error) {
+	ctx, done := observe(ctx, "ticket", "AttemptToReserveCapacity")
+	defer done()
+
+	filter := bson.M{
+		"_id":       ticketID,
+		"event_id":  eventID,
+		"type":      models.TicketTypeGeneralAdmission,
+		"remaining": bson.M{"$gte": quantity},
+	}
+	update := bson.A{
+		bson.M{"$set": bson.M{"remaining": bson.M{"$subtract": bson.A{"$remaining", quantity}}}},
+		bson.M{"$set": bson.M{"status": remainingStatus}},
+	}
+
+	var before models.Ticket
+	err := t.collection.FindOneAndUpdate(ctx, filter, update).Decode(&before)
+	if err == nil {
+		return TicketReservationAttemptResult{
+			TicketFound: true,
+			Reserved:    true,
+			Price:       before.Price,
+			Ticket:      before,
+		}, nil
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return TicketReservationAttemptResult{}, err
+	}
+
+	n, err := t.collection.CountDocuments(ctx, bson.M{"_id": ticketID, "event_id": eventID})
+	if err != nil {
+		return TicketReservationAttemptResult{}, err
+	}
+
+	return TicketReservationAttemptResult{
+		TicketFound: n > 0,
+	}, nil
+}
+
+// ReleaseCapacity gives quantity places of a general admission ticket back,
+// never exceeding its capacity.
+func (t *ticketRepository) ReleaseCapacity(ctx context.Context, eventID bson.ObjectID, ticketID bson.ObjectID, quantity int) error {
+	ctx, done := observe(ctx, "ticket", "ReleaseCapacity")
+	defer done()
+
+	filter := bson.M{
+		"_id":      ticketID,
+		"event_id": eventID,
+		"type":     models.TicketTypeGeneralAdmission,
+	}
+	update := bson.A{
+		bson.M{"$set": bson.M{"remaining": bson.M{"$min": bson.A{
+			"$capacity",
+			bson.M{"$add": bson.A{"$remaining", quantity}},
+		}}}},
+		bson.M{"$set": bson.M{"status": remainingStatus}},
+	}
+
+	res, err := t.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	return nil
+}
+
+// ResizeCapacity changes the capacity of a general admission ticket and
+// adjusts the remaining places by the same amount. It reports false without
+// changing anything if more places have been sold than the new capacity.
+func (t *ticketRepository) ResizeCapacity(ctx context.Context, eventID bson.ObjectID, ticketID bson.ObjectID, capacity int) (bool, error) {
+	ctx, done := observe(ctx, "ticket", "ResizeCapacity")
+	defer done()
+
+	filter := bson.M{
+		"_id":      ticketID,
+		"event_id": eventID,
+		"type":     models.TicketTypeGeneralAdmission,
+		"$expr": bson.M{"$lte": bson.A{
+			bson.M{"$subtract": bson.A{"$capacity", "$remaining"}},
+			capacity,
+		}},
+	}
+	update := bson.A{
+		bson.M{"$set": bson.M{
+			"remaining": bson.M{"$add": bson.A{"$remaining", bson.M{"$subtract": bson.A{capacity, "$capacity"}}}},
+			"capacity":  capacity,
+		}},
+		bson.M{"$set": bson.M{"status": remainingStatus}},
+	}
+
+	res, err := t.collection.UpdateOne(ctx, filter, update)
+	if err != nil {
+		return false, err
+	}
+
+	return res.MatchedCount > 0, nil
+}
@@ -0,0 +1,5 @@
+package requests
+
+type CreateCheckInRequest struct {
+	Token string `json:"token" validate:"required,lt=256" example:"9f86d081884c7d659a2feaa0c55ad015"`
+}
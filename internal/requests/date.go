@@ -0,0 +1,29 @@
+package requests
+
+import (
+	"errors"
+	"time"
+)
+
+const localDateTimeLayout = "2006-01-02T15:04:05"
+
+var ErrTimezoneRequired = errors.New("timezone is required for dates without a UTC offset")
+
+// ParseDate accepts either an RFC3339 timestamp or a local date-time without a
+// UTC offset, which is interpreted as wall-clock time in the given timezone.
+func ParseDate(value string, timezone string) (time.Time, error) {
+	if date, err := time.Parse(time.RFC3339, value); err == nil {
+		return date, nil
+	}
+
+	if timezone == "" {
+		return time.Time{}, ErrTimezoneRequired
+	}
+
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.ParseInLocation(localDateTimeLayout, value, loc)
+}
@@ -1,13 +1,51 @@
 package requests
 
 type CreateEventRequest struct {
-	Name  string `json:"name" validate:"required,lt=256" example:"FORMULA 1 ETIHAD AIRWAYS ABU DHABI GRAND PRIX 2025"`
-	Date  string `json:"date" validate:"required,datetime=2006-01-02T15:04:05Z07:00" example:"2025-12-07T16:00:00+03:00"`
-	Venue string `json:"venue" validate:"required,lt=256" example:"YTÜ Davutpaşa Tarihi Hamam"`
+	Name           string               `json:"name" validate:"required,lt=256" example:"FORMULA 1 ETIHAD AIRWAYS ABU DHABI GRAND PRIX 2025"`
+	Date           string               `json:"date" validate:"required,datetime=2006-01-02T15:04:05Z07:00|datetime=2006-01-02T15:04:05" example:"2025-12-07T17:00:00"`
+	Timezone       string               `json:"timezone,omitempty" validate:"omitempty,timezone" example:"Asia/Dubai"`
+	Venue          string               `json:"venue" validate:"required,lt=256" example:"YTÜ Davutpaşa Tarihi Hamam"`
+	ResalePriceCap int                  `json:"resale_price_cap,omitempty" validate:"omitempty,gt=0,lt=1000" example:"110"`
+	Category       string               `json:"category,omitempty" validate:"omitempty,lt=64" example:"Motorsport"`
+	Tags           []string             `json:"tags,omitempty" validate:"omitempty,max=20,dive,required,lt=64" example:"formula 1,racing"`
+	Address        *AddressRequest      `json:"address,omitempty" validate:"omitempty"`
+	Location       *LocationRequest     `json:"location,omitempty" validate:"omitempty"`
+	SeatingRules   *SeatingRulesRequest `json:"seating_rules,omitempty"`
 }
 
 type UpdateEventRequest struct {
-	Name  string `json:"name,omitempty" validate:"omitempty,lt=256" example:"FORMULA 1 ETIHAD AIRWAYS ABU DHABI GRAND PRIX 2025"`
-	Date  string `json:"date,omitempty" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00" example:"2025-12-07T16:00:00+03:00"`
-	Venue string `json:"venue,omitempty" validate:"omitempty,lt=256" example:"YTÜ Davutpaşa Tarihi Hamam"`
+	Name           string               `json:"name,omitempty" validate:"omitempty,lt=256" example:"FORMULA 1 ETIHAD AIRWAYS ABU DHABI GRAND PRIX 2025"`
+	Date           string               `json:"date,omitempty" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00|datetime=2006-01-02T15:04:05" example:"2025-12-07T17:00:00"`
+	Timezone       string               `json:"timezone,omitempty" validate:"omitempty,timezone" example:"Asia/Dubai"`
+	Venue          string               `json:"venue,omitempty" validate:"omitempty,lt=256" example:"YTÜ Davutpaşa Tarihi Hamam"`
+	ResalePriceCap int                  `json:"resale_price_cap,omitempty" validate:"omitempty,gt=0,lt=1000" example:"110"`
+	Category       string               `json:"category,omitempty" validate:"omitempty,lt=64" example:"Motorsport"`
+	Tags           []string             `json:"tags,omitempty" validate:"omitempty,max=20,dive,required,lt=64" example:"formula 1,racing"`
+	Address        *AddressRequest      `json:"address,omitempty" validate:"omitempty"`
+	Location       *LocationRequest     `json:"location,omitempty" validate:"omitempty"`
+	SeatingRules   *SeatingRulesRequest `json:"seating_rules,omitempty"`
+}
+
+type GetEventsRequest struct {
+	Status   string  `query:"status" validate:"omitempty,oneof=PUBLISHED ON_SALE SOLD_OUT CANCELLED COMPLETED" example:"ON_SALE"`
+	Day      string  `query:"day" validate:"omitempty,datetime=2006-01-02" example:"2025-12-07"`
+	Category string  `query:"category" validate:"omitempty,lt=64" example:"Motorsport"`
+	Tag      string  `query:"tag" validate:"omitempty,lt=64" example:"racing"`
+	Near     string  `query:"near" validate:"omitempty,latlong" example:"41.0247,28.8897"`
+	RadiusKm float64 `query:"radius_km" validate:"omitempty,gt=0,lt=20038" example:"25"`
+}
+
+type SearchEventsRequest struct {
+	Query    string `query:"q" validate:"required,lt=256" example:"grand prix"`
+	Category string `query:"category" validate:"omitempty,lt=64" example:"Motorsport"`
+	Venue    string `query:"venue" validate:"omitempty,lt=256" example:"YTÜ Davutpaşa Tarihi Hamam"`
+	Month    string `query:"month" validate:"omitempty,datetime=2006-01" example:"2025-12"`
+	Limit    int    `query:"limit" validate:"omitempty,gt=0,lt=101" example:"20"`
+}
+
+type CloneEventRequest struct {
+	Date     string `json:"date" validate:"required,datetime=2006-01-02T15:04:05Z07:00|datetime=2006-01-02T15:04:05" example:"2026-12-06T17:00:00"`
+	Timezone string `json:"timezone,omitempty" validate:"omitempty,timezone" example:"Asia/Dubai"`
+	Name     string `json:"name,omitempty" validate:"omitempty,lt=256" example:"FORMULA 1 ETIHAD AIRWAYS ABU DHABI GRAND PRIX 2026"`
+	Venue    string `json:"venue,omitempty" validate:"omitempty,lt=256" example:"YTÜ Davutpaşa Tarihi Hamam"`
 }
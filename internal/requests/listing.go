@@ -0,0 +1,15 @@
+package requests
+
+type CreateListingRequest struct {
+	TicketID string `json:"ticket_id" validate:"required,objectid" example:"68f2ab0516a352dc8f40c543"`
+	Token    string `json:"token" validate:"required,lt=256" example:"9f86d081884c7d659a2feaa0c55ad015"`
+	Price    int    `json:"price" validate:"required,gt=0" example:"5499"`
+}
+
+type CancelListingRequest struct {
+	Token string `json:"token" validate:"required,lt=256" example:"9f86d081884c7d659a2feaa0c55ad015"`
+}
+
+type PurchaseListingRequest struct {
+	CustomerName string `json:"customer_name" validate:"required,lt=256" example:"Max Verstappen"`
+}
@@ -0,0 +1,7 @@
+package requests
+
+type ConfigureQueueRequest struct {
+	Enabled         bool `json:"enabled" example:"true"`
+	BatchSize       int  `json:"batch_size" validate:"required,min=1,max=10000" example:"100"`
+	IntervalSeconds int  `json:"interval_seconds" validate:"required,min=1,max=3600" example:"30"`
+}
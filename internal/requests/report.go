@@ -0,0 +1,10 @@
+package requests
+
+type GetSalesSummaryRequest struct {
+	From string `query:"from" validate:"required,datetime=2006-01-02" example:"2025-12-01"`
+	To   string `query:"to" validate:"required,datetime=2006-01-02" example:"2025-12-31"`
+}
+
+type GetManifestRequest struct {
+	Sort string `query:"sort" validate:"omitempty,oneof=seat name" example:"seat"`
+}
@@ -2,8 +2,25 @@ package requests
 
 type CreateReservationRequest struct {
 	CustomerName string `json:"customer_name" validate:"required,lt=256" example:"Enes Genç"`
+	Quantity     int    `json:"quantity,omitempty" validate:"omitempty,min=1,max=10" example:"1"`
 }
 
-type UpdateReservationRequest struct {
-	CustomerName string `json:"customer_name,omitempty" validate:"omitempty,lt=256" example:"Enes Genç"`
+type ReservationTokenQuery struct {
+	Token string `query:"token" example:"9f86d081884c7d659a2feaa0c55ad015"`
+}
+
+type CreateTransferRequest struct {
+	Token         string `json:"token" validate:"required,lt=256" example:"9f86d081884c7d659a2feaa0c55ad015"`
+	RecipientName string `json:"recipient_name" validate:"required,lt=256" example:"Max Verstappen"`
+}
+
+type AcceptTransferRequest struct {
+	Code string `json:"code" validate:"required,lt=256" example:"3c59dc048e8850243be8079a5c74d079"`
+}
+
+type BestAvailableRequest struct {
+	CustomerName string `json:"customer_name" validate:"required,lt=256" example:"Enes Genç"`
+	Quantity     int    `json:"quantity" validate:"required,min=1,max=10" example:"2"`
+	MaxPrice     int    `json:"max_price,omitempty" validate:"omitempty,gt=0" example:"7500"`
+	Section      string `json:"section,omitempty" validate:"omitempty,lt=64" example:"Grandstand"`
 }
@@ -0,0 +1,24 @@
+package requests
+
+type CreateSeriesRequest struct {
+	Name           string                `json:"name" validate:"required,lt=256" example:"The Phantom of the Opera"`
+	Venue          string                `json:"venue" validate:"required,lt=256" example:"Zorlu PSM"`
+	Start          string                `json:"start" validate:"required,datetime=2006-01-02T15:04:05Z07:00|datetime=2006-01-02T15:04:05" example:"2025-12-01T20:00:00"`
+	Timezone       string                `json:"timezone,omitempty" validate:"omitempty,timezone" example:"Europe/Istanbul"`
+	Recurrence     string                `json:"recurrence" validate:"required,lt=256" example:"FREQ=DAILY;COUNT=30"`
+	ResalePriceCap int                   `json:"resale_price_cap,omitempty" validate:"omitempty,gt=0,lt=1000" example:"110"`
+	Tickets        []CreateTicketRequest `json:"tickets,omitempty" validate:"omitempty,dive"`
+	Address        *AddressRequest       `json:"address,omitempty" validate:"omitempty"`
+	Location       *LocationRequest      `json:"location,omitempty" validate:"omitempty"`
+	Category       string                `json:"category,omitempty" validate:"omitempty,lt=64" example:"Musical"`
+	Tags           []string              `json:"tags,omitempty" validate:"omitempty,max=20,dive,required,lt=64" example:"theatre,broadway"`
+	SeatingRules   *SeatingRulesRequest  `json:"seating_rules,omitempty"`
+}
+
+type UpdateSeriesRequest struct {
+	Name           string           `json:"name,omitempty" validate:"omitempty,lt=256" example:"The Phantom of the Opera"`
+	Venue          string           `json:"venue,omitempty" validate:"omitempty,lt=256" example:"Zorlu PSM"`
+	ResalePriceCap int              `json:"resale_price_cap,omitempty" validate:"omitempty,gt=0,lt=1000" example:"110"`
+	Address        *AddressRequest  `json:"address,omitempty" validate:"omitempty"`
+	Location       *LocationRequest `json:"location,omitempty" validate:"omitempty"`
+}
@@ -1,11 +1,25 @@
 package requests
 
 type CreateTicketRequest struct {
-	SeatNumber string `json:"seat_number" validate:"required,lt=256" example:"A12"`
+	SeatNumber string `json:"seat_number,omitempty" validate:"required_without=Capacity,excluded_with=Capacity,omitempty,lt=256" example:"A12"`
 	Price      int    `json:"price" validate:"required,gt=0" example:"4999"`
+	Category   string `json:"category,omitempty" validate:"required_with=Capacity,omitempty,lt=64" example:"Grandstand"`
+	Section    string `json:"section,omitempty" validate:"omitempty,lt=64" example:"North Stand"`
+	Row        string `json:"row,omitempty" validate:"required_with=Seat,excluded_with=Capacity,omitempty,alphanum,lt=16" example:"A"`
+	Seat       int    `json:"seat,omitempty" validate:"required_with=Row,excluded_with=Capacity,omitempty,gt=0" example:"12"`
+	Capacity   int    `json:"capacity,omitempty" validate:"omitempty,gt=0,lte=1000000" example:"500"`
 }
 
 type UpdateTicketRequest struct {
 	SeatNumber string `json:"seat_number" validate:"omitempty,lt=256" example:"A12"`
 	Price      int    `json:"price" validate:"omitempty,gt=0" example:"4999"`
+	Category   string `json:"category,omitempty" validate:"omitempty,lt=64" example:"Grandstand"`
+	Section    string `json:"section,omitempty" validate:"omitempty,lt=64" example:"North Stand"`
+	Row        string `json:"row,omitempty" validate:"required_with=Seat,omitempty,alphanum,lt=16" example:"A"`
+	Seat       int    `json:"seat,omitempty" validate:"required_with=Row,omitempty,gt=0" example:"12"`
+	Capacity   int    `json:"capacity,omitempty" validate:"omitempty,gt=0,lte=1000000" example:"500"`
+}
+
+type ImportTicketsRequest struct {
+	DryRun bool `query:"dry_run" example:"true"`
 }
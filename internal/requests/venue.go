@@ -0,0 +1,17 @@
+package requests
+
+type AddressRequest struct {
+	Street     string `json:"street,omitempty" validate:"omitempty,lt=256" example:"Davutpaşa Cd. No:127"`
+	City       string `json:"city" validate:"required,lt=128" example:"İstanbul"`
+	PostalCode string `json:"postal_code,omitempty" validate:"omitempty,lt=32" example:"34220"`
+	Country    string `json:"country" validate:"required,iso3166_1_alpha2" example:"TR"`
+}
+
+type SeatingRulesRequest struct {
+	NoSingleGaps bool `json:"no_single_gaps" example:"true"`
+}
+
+type LocationRequest struct {
+	Latitude  *float64 `json:"latitude" validate:"required,latitude" example:"41.0247"`
+	Longitude *float64 `json:"longitude" validate:"required,longitude" example:"28.8897"`
+}
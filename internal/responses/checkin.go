@@ -0,0 +1,9 @@
+package responses
+
+import "time"
+
+type AlreadyCheckedInResponse struct {
+	Message     string    `json:"message" example:"Ticket has already been checked in"`
+	CheckedInAt time.Time `json:"checked_in_at" example:"2025-12-07T18:12:43Z"`
+	RequestID   string    `json:"request_id,omitempty" example:"4f1c2a7e-9b3d-4e8a-a6f0-2d5c8b1e7a93"`
+}
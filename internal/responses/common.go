@@ -3,9 +3,11 @@ package responses
 import "github.com/enxg/skyticket/pkg/validator"
 
 type ValidationErrorResponse struct {
-	Errors []validator.ValidationError `json:"errors"`
+	Errors    []validator.ValidationError `json:"errors"`
+	RequestID string                      `json:"request_id,omitempty" example:"4f1c2a7e-9b3d-4e8a-a6f0-2d5c8b1e7a93"`
 }
 
 type ErrorResponse struct {
-	Message string `json:"message" example:"Error message"`
+	Message   string `json:"message" example:"Error message"`
+	RequestID string `json:"request_id,omitempty" example:"4f1c2a7e-9b3d-4e8a-a6f0-2d5c8b1e7a93"`
 }
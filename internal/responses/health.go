@@ -0,0 +1,5 @@
+package responses
+
+type HealthResponse struct {
+	Status string `json:"status" example:"ok"`
+}
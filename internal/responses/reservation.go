@@ -0,0 +1,9 @@
+package responses
+
+import "time"
+
+type TransferResponse struct {
+	RecipientName string    `json:"recipient_name" example:"Max Verstappen"`
+	Code          string    `json:"code" example:"3c59dc048e8850243be8079a5c74d079"`
+	ExpiresAt     time.Time `json:"expires_at" example:"2025-11-22T10:30:00Z"`
+}
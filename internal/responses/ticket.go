@@ -0,0 +1,20 @@
+package responses
+
+import (
+	"github.com/enxg/skyticket/internal/models"
+	"github.com/enxg/skyticket/pkg/validator"
+)
+
+type TicketImportResponse struct {
+	DryRun  bool                   `json:"dry_run" example:"true"`
+	Rows    int                    `json:"rows" example:"250"`
+	Valid   int                    `json:"valid" example:"248"`
+	Created int                    `json:"created" example:"0"`
+	Errors  []TicketImportRowError `json:"errors"`
+	Tickets []models.Ticket        `json:"tickets,omitempty"`
+}
+
+type TicketImportRowError struct {
+	Line   int                         `json:"line" example:"17"`
+	Errors []validator.ValidationError `json:"errors"`
+}
@@ -0,0 +1,77 @@
+package router
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/enxg/skyticket/internal/logging"
+	"github.com/enxg/skyticket/internal/responses"
+	"github.com/enxg/skyticket/pkg/validator"
+	"github.com/gofiber/fiber/v3"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+// ErrorHandler turns errors returned by handlers into JSON error responses
+// carrying the request ID, so that users can quote it in bug reports.
+// Controllers return expected failures as *fiber.Error with the message to
+// show.
+func ErrorHandler(ctx fiber.Ctx, err error) error {
+	requestID := logging.RequestID(ctx.Context())
+
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		return ctx.Status(fiber.StatusBadRequest).
+			JSON(responses.ValidationErrorResponse{
+				Errors:    validator.ParseValidationErrors(validationErrors),
+				RequestID: requestID,
+			})
+	}
+
+	if errors.Is(err, mongo.ErrNoDocuments) || errors.Is(err, bson.ErrInvalidHex) {
+		return ctx.Status(fiber.StatusNotFound).JSON(responses.ErrorResponse{
+			Message:   "Resource not found",
+			RequestID: requestID,
+		})
+	}
+
+	var jte *json.UnmarshalTypeError
+	if errors.As(err, &jte) {
+		return ctx.Status(fiber.StatusBadRequest).JSON(responses.ValidationErrorResponse{
+			Errors: []validator.ValidationError{
+				{Field: jte.Field, Error: "invalid type provided, expected " + jte.Type.String() + "."},
+			},
+			RequestID: requestID,
+		})
+	}
+
+	var jse *json.SyntaxError
+	if errors.As(err, &jse) {
+		return ctx.Status(fiber.StatusBadRequest).JSON(responses.ErrorResponse{
+			Message:   "Malformed JSON at offset " + fmt.Sprint(jse.Offset) + ".",
+			RequestID: requestID,
+		})
+	}
+
+	code := fiber.StatusInternalServerError
+	message := "Internal server error"
+
+	// Expected failures are already recorded by the access log.
+	var e *fiber.Error
+	if errors.As(err, &e) {
+		code = e.Code
+		message = e.Message
+	} else {
+		logging.FromContext(ctx.Context()).Error().
+			Str("path", ctx.Path()).
+			Str("type", fmt.Sprintf("%T", err)).
+			Err(err).
+			Send()
+	}
+
+	return ctx.Status(code).JSON(responses.ErrorResponse{
+		Message:   message,
+		RequestID: requestID,
+	})
+}
@@ -3,6 +3,7 @@ package router
 import (
 	"github.com/enxg/skyticket/docs"
 	"github.com/enxg/skyticket/internal/controllers"
+	"github.com/enxg/skyticket/internal/metrics"
 	"github.com/gofiber/fiber/v3"
 	"github.com/yokeTH/gofiber-scalar/scalar/v3"
 )
@@ -11,28 +12,90 @@ type Controllers struct {
 	EventController       controllers.EventController
 	TicketController      controllers.TicketController
 	ReservationController controllers.ReservationController
+	CheckInController     controllers.CheckInController
+	ResaleController      controllers.ResaleController
+	SeriesController      controllers.SeriesController
+	ReportController      controllers.ReportController
+	HealthController      controllers.HealthController
+	QueueController       controllers.QueueController
 }
 
-func SetupRoutes(app *fiber.App, c Controllers) {
+type Middlewares struct {
+	RateLimit            fiber.Handler
+	ReservationRateLimit fiber.Handler
+}
+
+func SetupRoutes(app *fiber.App, c Controllers, m Middlewares) {
+	// Probes and metrics are registered before the rate limiter so that
+	// orchestrators and scrapers are never limited.
+	app.Get("/healthz", c.HealthController.GetLiveness)
+	app.Get("/readyz", c.HealthController.GetReadiness)
+	app.Get("/metrics", metrics.Handler())
+
+	app.Use(m.RateLimit)
+
 	app.Group("/events").
 		Post("/", c.EventController.CreateEvent).
+		Post("/import", c.EventController.ImportEvent).
+		Get("/search", c.EventController.SearchEvents).
 		Get("/:id", c.EventController.GetEventByID).
 		Get("/", c.EventController.GetAllEvents).
 		Patch("/:id", c.EventController.UpdateEvent).
-		Delete("/:id", c.EventController.DeleteEvent)
+		Delete("/:id", c.EventController.DeleteEvent).
+		Post("/:id/publish", c.EventController.PublishEvent).
+		Post("/:id/on-sale", c.EventController.StartEventSales).
+		Post("/:id/cancel", c.EventController.CancelEvent).
+		Post("/:id/clone", c.EventController.CloneEvent).
+		Get("/:id/export", c.EventController.ExportEvent).
+		Get("/:id/stats", c.ReportController.GetEventStats).
+		Get("/:id/manifest", c.ReportController.ExportManifest)
+
+	app.Group("/series").
+		Post("/", c.SeriesController.CreateSeries).
+		Get("/:id", c.SeriesController.GetSeriesByID).
+		Get("/:id/events", c.SeriesController.GetSeriesEvents).
+		Patch("/:id", c.SeriesController.UpdateSeries)
 
 	app.Group("/events/:eventId/tickets").
 		Post("/", c.TicketController.CreateTicket).
+		Post("/import", c.TicketController.ImportTickets).
 		Get("/:id", c.TicketController.GetTicketByID).
 		Get("/", c.TicketController.GetAllTickets).
 		Patch("/:id", c.TicketController.UpdateTicket).
 		Delete("/:id", c.TicketController.DeleteTicket)
 
 	app.Group("/events/:eventId/tickets/:ticketId/reservation").
-		Post("/", c.ReservationController.CreateReservation).
+		Post("/", m.ReservationRateLimit, c.QueueController.RequireAdmission, c.ReservationController.CreateReservation).
 		Get("/", c.ReservationController.GetReservationByID).
-		Patch("/", c.ReservationController.UpdateReservation).
-		Delete("/", c.ReservationController.DeleteReservation)
+		Delete("/", c.ReservationController.DeleteReservation).
+		Post("/transfer", c.ReservationController.StartTransfer).
+		Post("/transfer/accept", c.ReservationController.AcceptTransfer)
+
+	app.Group("/events/:eventId/reservations").
+		Post("/best-available", m.ReservationRateLimit, c.QueueController.RequireAdmission, c.ReservationController.ReserveBestAvailable)
+
+	app.Group("/events/:eventId/queue").
+		Put("/", c.QueueController.ConfigureQueue).
+		Get("/", c.QueueController.GetQueue).
+		Post("/join", m.ReservationRateLimit, c.QueueController.JoinQueue).
+		Get("/position", c.QueueController.GetQueuePosition).
+		Get("/position/stream", c.QueueController.StreamQueuePosition)
+
+	app.Group("/events/:eventId/checkins").
+		Post("/", c.CheckInController.CreateCheckIn).
+		Get("/stats", c.CheckInController.GetCheckInStats)
+
+	app.Group("/events/:eventId/listings").
+		Post("/", c.ResaleController.CreateListing).
+		Get("/:id", c.ResaleController.GetListingByID).
+		Get("/", c.ResaleController.GetAllListings).
+		Delete("/:id", c.ResaleController.CancelListing).
+		Post("/:id/purchase", c.ResaleController.PurchaseListing)
+
+	app.Get("/events/:eventId/payouts", c.ResaleController.GetAllPayouts)
+
+	app.Group("/reports").
+		Get("/summary", c.ReportController.GetSalesSummary)
 
 	app.Group("/docs").
 		Use(scalar.New(scalar.Config{
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/enxg/skyticket/internal/models"
+	"github.com/enxg/skyticket/internal/repositories"
+	"github.com/enxg/skyticket/internal/tracing"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type CheckInService interface {
+	CheckIn(ctx context.Context, eventID string, token string) (models.Reservation, error)
+	GetCheckInStats(ctx context.Context, eventID string) (models.CheckInStats, error)
+}
+
+type checkInService struct {
+	reservationRepository repositories.ReservationRepository
+	eventRepository       repositories.EventRepository
+}
+
+var (
+	ErrInvalidTicketToken   = errors.New("invalid ticket token")
+	ErrAlreadyCheckedIn     = errors.New("ticket has already been checked in")
+	ErrReservationNotActive = errors.New("reservation is not active")
+)
+
+func NewCheckInService(reservationRepository repositories.ReservationRepository, eventRepository repositories.EventRepository) CheckInService {
+	return &checkInService{
+		reservationRepository: reservationRepository,
+		eventRepository:       eventRepository,
+	}
+}
+
+func (c *checkInService) CheckIn(ctx context.Context, eventID string, token string) (models.Reservation, error) {
+	ctx, span := tracing.Start(ctx, "CheckInService.CheckIn")
+	defer span.End()
+
+	eventOid, err := bson.ObjectIDFromHex(eventID)
+	if err != nil {
+		return models.Reservation{}, err
+	}
+
+	_, err = c.eventRepository.FindOneByID(ctx, eventOid)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.Reservation{}, ErrEventNotFound
+		}
+		return models.Reservation{}, err
+	}
+
+	res, err := c.reservationRepository.AttemptToCheckIn(ctx, eventOid, token, time.Now())
+	if err != nil {
+		return models.Reservation{}, err
+	}
+
+	if !res.ReservationFound {
+		return models.Reservation{}, ErrInvalidTicketToken
+	}
+
+	if !res.CheckedIn {
+		if res.Reservation.Status == models.ReservationStatusCheckedIn {
+			return res.Reservation, ErrAlreadyCheckedIn
+		}
+		return models.Reservation{}, ErrReservationNotActive
+	}
+
+	return res.Reservation, nil
+}
+
+func (c *checkInService) GetCheckInStats(ctx context.Context, eventID string) (models.CheckInStats, error) {
+	ctx, span := tracing.Start(ctx, "CheckInService.GetCheckInStats")
+	defer span.End()
+
+	eventOid, err := bson.ObjectIDFromHex(eventID)
+	if err != nil {
+		return models.CheckInStats{}, err
+	}
+
+	_, err = c.eventRepository.FindOneByID(ctx, eventOid)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.CheckInStats{}, ErrEventNotFound
+		}
+		return models.CheckInStats{}, err
+	}
+
+	active, err := c.reservationRepository.CountPlaces(ctx, models.Reservation{
+		EventID: eventOid,
+		Status:  models.ReservationStatusActive,
+	})
+	if err != nil {
+		return models.CheckInStats{}, err
+	}
+
+	checkedIn, err := c.reservationRepository.CountPlaces(ctx, models.Reservation{
+		EventID: eventOid,
+		Status:  models.ReservationStatusCheckedIn,
+	})
+	if err != nil {
+		return models.CheckInStats{}, err
+	}
+
+	return models.CheckInStats{
+		EventID:   eventOid,
+		Reserved:  active + checkedIn,
+		CheckedIn: checkedIn,
+	}, nil
+}
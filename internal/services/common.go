@@ -1,7 +1,23 @@
 package services
 
-import "errors"
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+)
 
 var (
-	ErrEventAlreadyPassed = errors.New("event date has already passed")
+	ErrEventAlreadyPassed     = errors.New("event date has already passed")
+	ErrEventNotOnSale         = errors.New("event is not on sale")
+	ErrEventCancelled         = errors.New("event has been cancelled")
+	ErrInvalidEventTransition = errors.New("invalid event status transition")
 )
+
+func generateToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
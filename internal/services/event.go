@@ -2,44 +2,95 @@ package services
 
 import (
 	"context"
+	"errors"
+	"slices"
 	"time"
 
+	"github.com/enxg/skyticket/internal/metrics"
 	"github.com/enxg/skyticket/internal/models"
 	"github.com/enxg/skyticket/internal/repositories"
+	"github.com/enxg/skyticket/internal/tracing"
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 )
 
 type EventService interface {
-	CreateEvent(ctx context.Context, name string, date time.Time, venue string) (models.Event, error)
+	CreateEvent(ctx context.Context, input EventInput) (models.Event, error)
 	GetEventByID(ctx context.Context, id string) (models.Event, error)
-	GetAllEvents(ctx context.Context) ([]models.Event, error)
-	UpdateEvent(ctx context.Context, id string, name string, date time.Time, venue string) (models.Event, error)
+	GetAllEvents(ctx context.Context, status models.EventStatus, day string, category string, tag string, near *models.GeoPoint, radiusKm float64) ([]models.Event, error)
+	SearchEvents(ctx context.Context, query string, category string, venue string, month string, limit int) (models.EventSearchResult, error)
+	UpdateEvent(ctx context.Context, id string, input EventInput) (models.Event, error)
 	DeleteEvent(ctx context.Context, id string) error
+	TransitionEvent(ctx context.Context, id string, status models.EventStatus) (models.Event, error)
+	CompletePastEvents(ctx context.Context) (int64, error)
+	CloneEvent(ctx context.Context, id string, date time.Time, timezone string, name string, venue string) (models.Event, error)
+	ExportEvent(ctx context.Context, id string) (models.EventArchive, error)
+	ImportEvent(ctx context.Context, archive models.EventArchive) (models.Event, error)
 }
 
+// EventInput holds the details of an event that are set on creation. On
+// update, zero values leave the current value unchanged.
+type EventInput struct {
+	Name           string
+	Date           time.Time
+	Timezone       string
+	Venue          string
+	ResalePriceCap int
+	Category       string
+	Tags           []string
+	Address        *models.Address
+	Location       *models.GeoPoint
+	SeatingRules   *models.SeatingRules
+}
+
+var eventTransitions = map[models.EventStatus][]models.EventStatus{
+	models.EventStatusDraft:     {models.EventStatusPublished, models.EventStatusCancelled},
+	models.EventStatusPublished: {models.EventStatusOnSale, models.EventStatusCancelled},
+	models.EventStatusOnSale:    {models.EventStatusCancelled},
+	models.EventStatusSoldOut:   {models.EventStatusCancelled},
+}
+
+var (
+	ErrUnsupportedArchiveVersion = errors.New("unsupported event archive version")
+	ErrInvalidArchive            = errors.New("invalid event archive")
+)
+
+const (
+	defaultSearchLimit  = 20
+	defaultNearRadiusKm = 25
+)
+
 type eventService struct {
 	eventRepository       repositories.EventRepository
 	ticketRepository      repositories.TicketRepository
 	reservationRepository repositories.ReservationRepository
+	listingRepository     repositories.ListingRepository
 	mongoClient           *mongo.Client
 }
 
-func NewEventService(eventRepository repositories.EventRepository, ticketRepository repositories.TicketRepository, reservationRepository repositories.ReservationRepository, mongoClient *mongo.Client) EventService {
+func NewEventService(eventRepository repositories.EventRepository, ticketRepository repositories.TicketRepository, reservationRepository repositories.ReservationRepository, listingRepository repositories.ListingRepository, mongoClient *mongo.Client) EventService {
 	return &eventService{
 		eventRepository:       eventRepository,
 		ticketRepository:      ticketRepository,
 		reservationRepository: reservationRepository,
+		listingRepository:     listingRepository,
 		mongoClient:           mongoClient,
 	}
 }
 
-func (e *eventService) CreateEvent(ctx context.Context, name string, date time.Time, venue string) (models.Event, error) {
-	res, err := e.eventRepository.Create(ctx, models.Event{
-		Name:  name,
-		Date:  date,
-		Venue: venue,
-	})
+func (e *eventService) CreateEvent(ctx context.Context, input EventInput) (models.Event, error) {
+	ctx, span := tracing.Start(ctx, "EventService.CreateEvent")
+	defer span.End()
+
+	event := input.event()
+	event.Status = models.EventStatusDraft
+
+	err := localizeEvent(&event)
+	if err != nil {
+		return models.Event{}, err
+	}
+
+	res, err := e.eventRepository.Create(ctx, event)
 	if err != nil {
 		return models.Event{}, err
 	}
@@ -48,33 +99,135 @@ func (e *eventService) CreateEvent(ctx context.Context, name string, date time.T
 }
 
 func (e *eventService) GetEventByID(ctx context.Context, id string) (models.Event, error) {
+	ctx, span := tracing.Start(ctx, "EventService.GetEventByID")
+	defer span.End()
+
 	oid, err := bson.ObjectIDFromHex(id)
 	if err != nil {
 		return models.Event{}, err
 	}
 
-	return e.eventRepository.FindOneByID(ctx, oid)
+	event, err := e.eventRepository.FindOneByID(ctx, oid)
+	if err != nil {
+		return models.Event{}, err
+	}
+
+	// Drafts are not announced yet, so they look the same as missing events.
+	if event.Status == models.EventStatusDraft {
+		return models.Event{}, mongo.ErrNoDocuments
+	}
+
+	return event, nil
 }
 
-func (e *eventService) GetAllEvents(ctx context.Context) ([]models.Event, error) {
-	return e.eventRepository.Find(ctx, models.Event{})
+func (e *eventService) GetAllEvents(ctx context.Context, status models.EventStatus, day string, category string, tag string, near *models.GeoPoint, radiusKm float64) ([]models.Event, error) {
+	ctx, span := tracing.Start(ctx, "EventService.GetAllEvents")
+	defer span.End()
+
+	if near != nil && radiusKm == 0 {
+		radiusKm = defaultNearRadiusKm
+	}
+
+	return e.eventRepository.List(ctx, repositories.EventQuery{
+		Status:        status,
+		ExcludeStatus: models.EventStatusDraft,
+		LocalDay:      day,
+		Category:      category,
+		Tag:           tag,
+		Near:          near,
+		RadiusKm:      radiusKm,
+	})
 }
 
-func (e *eventService) UpdateEvent(ctx context.Context, id string, name string, date time.Time, venue string) (models.Event, error) {
+func (e *eventService) SearchEvents(ctx context.Context, query string, category string, venue string, month string, limit int) (models.EventSearchResult, error) {
+	ctx, span := tracing.Start(ctx, "EventService.SearchEvents")
+	defer span.End()
+
+	if limit == 0 {
+		limit = defaultSearchLimit
+	}
+
+	res, err := e.eventRepository.Search(ctx, repositories.EventSearchQuery{
+		Text:          query,
+		ExcludeStatus: models.EventStatusDraft,
+		Category:      category,
+		Venue:         venue,
+		Month:         month,
+		Limit:         limit,
+	})
+	if err != nil {
+		return models.EventSearchResult{}, err
+	}
+
+	if res.Results == nil {
+		res.Results = []models.ScoredEvent{}
+	}
+	if res.Facets.Categories == nil {
+		res.Facets.Categories = []models.FacetCount{}
+	}
+	if res.Facets.Venues == nil {
+		res.Facets.Venues = []models.FacetCount{}
+	}
+	if res.Facets.Months == nil {
+		res.Facets.Months = []models.FacetCount{}
+	}
+
+	return res, nil
+}
+
+func (e *eventService) UpdateEvent(ctx context.Context, id string, input EventInput) (models.Event, error) {
+	ctx, span := tracing.Start(ctx, "EventService.UpdateEvent")
+	defer span.End()
+
 	oid, err := bson.ObjectIDFromHex(id)
 	if err != nil {
 		return models.Event{}, err
 	}
 
-	return e.eventRepository.Update(ctx, models.Event{
-		ID:    oid,
-		Name:  name,
-		Date:  date,
-		Venue: venue,
-	})
+	event := input.event()
+	event.ID = oid
+
+	if !input.Date.IsZero() || input.Timezone != "" {
+		current, err := e.eventRepository.FindOneByID(ctx, oid)
+		if err != nil {
+			return models.Event{}, err
+		}
+
+		if event.Date.IsZero() {
+			event.Date = current.Date
+		}
+		if event.Timezone == "" {
+			event.Timezone = current.Timezone
+		}
+
+		err = localizeEvent(&event)
+		if err != nil {
+			return models.Event{}, err
+		}
+	}
+
+	return e.eventRepository.Update(ctx, event)
+}
+
+func (i EventInput) event() models.Event {
+	return models.Event{
+		Name:           i.Name,
+		Date:           i.Date,
+		Timezone:       i.Timezone,
+		Venue:          i.Venue,
+		ResalePriceCap: i.ResalePriceCap,
+		Category:       i.Category,
+		Tags:           i.Tags,
+		Address:        i.Address,
+		Location:       i.Location,
+		SeatingRules:   i.SeatingRules,
+	}
 }
 
 func (e *eventService) DeleteEvent(ctx context.Context, id string) error {
+	ctx, span := tracing.Start(ctx, "EventService.DeleteEvent")
+	defer span.End()
+
 	oid, err := bson.ObjectIDFromHex(id)
 	if err != nil {
 		return err
@@ -84,7 +237,7 @@ func (e *eventService) DeleteEvent(ctx context.Context, id string) error {
 	if err != nil {
 		return err
 	}
-	_, err = tx.WithTransaction(ctx, func(txCtx context.Context) (any, error) {
+	_, err = tx.WithTransaction(ctx, metrics.InstrumentTransaction("delete_event", func(txCtx context.Context) (any, error) {
 		err := e.reservationRepository.DeleteMany(txCtx, models.Reservation{
 			EventID: oid,
 		})
@@ -99,9 +252,357 @@ func (e *eventService) DeleteEvent(ctx context.Context, id string) error {
 			return nil, err
 		}
 
+		err = e.listingRepository.DeleteMany(txCtx, models.Listing{
+			EventID: oid,
+		})
+		if err != nil {
+			return nil, err
+		}
+
 		err = e.eventRepository.Delete(ctx, oid)
 		return nil, err
+	}))
+
+	return err
+}
+
+func (e *eventService) TransitionEvent(ctx context.Context, id string, status models.EventStatus) (models.Event, error) {
+	ctx, span := tracing.Start(ctx, "EventService.TransitionEvent")
+	defer span.End()
+
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return models.Event{}, err
+	}
+
+	event, err := e.eventRepository.FindOneByID(ctx, oid)
+	if err != nil {
+		return models.Event{}, err
+	}
+
+	if !slices.Contains(eventTransitions[event.EffectiveStatus()], status) {
+		return models.Event{}, ErrInvalidEventTransition
+	}
+
+	if status != models.EventStatusCancelled && event.Date.Before(time.Now()) {
+		return models.Event{}, ErrEventAlreadyPassed
+	}
+
+	ok, err := e.eventRepository.TransitionStatus(ctx, oid, []models.EventStatus{event.EffectiveStatus()}, status)
+	if err != nil {
+		return models.Event{}, err
+	}
+
+	if !ok {
+		return models.Event{}, ErrInvalidEventTransition
+	}
+
+	if status == models.EventStatusOnSale {
+		err = refreshSalesStatus(ctx, e.eventRepository, e.ticketRepository, oid)
+		if err != nil {
+			return models.Event{}, err
+		}
+	}
+
+	return e.eventRepository.FindOneByID(ctx, oid)
+}
+
+func (e *eventService) CompletePastEvents(ctx context.Context) (int64, error) {
+	ctx, span := tracing.Start(ctx, "EventService.CompletePastEvents")
+	defer span.End()
+
+	return e.eventRepository.CompletePastEvents(ctx, time.Now())
+}
+
+func (e *eventService) CloneEvent(ctx context.Context, id string, date time.Time, timezone string, name string, venue string) (models.Event, error) {
+	ctx, span := tracing.Start(ctx, "EventService.CloneEvent")
+	defer span.End()
+
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return models.Event{}, err
+	}
+
+	tx, err := e.mongoClient.StartSession()
+	if err != nil {
+		return models.Event{}, err
+	}
+	defer tx.EndSession(ctx)
+
+	event, err := tx.WithTransaction(ctx, metrics.InstrumentTransaction("clone_event", func(txCtx context.Context) (any, error) {
+		source, err := e.eventRepository.FindOneByID(txCtx, oid)
+		if err != nil {
+			return models.Event{}, err
+		}
+
+		clone := source
+		clone.ID = bson.ObjectID{}
+		clone.SeriesID = bson.ObjectID{}
+		clone.Status = models.EventStatusDraft
+		clone.Date = date
+		if timezone != "" {
+			clone.Timezone = timezone
+		}
+		if name != "" {
+			clone.Name = name
+		}
+		if venue != "" {
+			clone.Venue = venue
+		}
+
+		err = localizeEvent(&clone)
+		if err != nil {
+			return models.Event{}, err
+		}
+
+		clone, err = e.eventRepository.Create(txCtx, clone)
+		if err != nil {
+			return models.Event{}, err
+		}
+
+		tickets, err := e.ticketRepository.Find(txCtx, models.Ticket{
+			EventID: oid,
+		})
+		if err != nil {
+			return models.Event{}, err
+		}
+
+		if len(tickets) == 0 {
+			return clone, nil
+		}
+
+		for i := range tickets {
+			tickets[i].ID = bson.ObjectID{}
+			tickets[i].EventID = clone.ID
+			tickets[i].Status = models.TicketStatusAvailable
+			tickets[i].Remaining = tickets[i].Capacity
+		}
+
+		_, err = e.ticketRepository.CreateMany(txCtx, tickets)
+		if err != nil {
+			return models.Event{}, err
+		}
+
+		return clone, nil
+	}))
+	if err != nil {
+		return models.Event{}, err
+	}
+
+	return event.(models.Event), nil
+}
+
+func (e *eventService) ExportEvent(ctx context.Context, id string) (models.EventArchive, error) {
+	ctx, span := tracing.Start(ctx, "EventService.ExportEvent")
+	defer span.End()
+
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return models.EventArchive{}, err
+	}
+
+	event, err := e.eventRepository.FindOneByID(ctx, oid)
+	if err != nil {
+		return models.EventArchive{}, err
+	}
+
+	tickets, err := e.ticketRepository.Find(ctx, models.Ticket{
+		EventID: oid,
 	})
+	if err != nil {
+		return models.EventArchive{}, err
+	}
+
+	reservations, err := e.reservationRepository.Find(ctx, models.Reservation{
+		EventID: oid,
+	})
+	if err != nil {
+		return models.EventArchive{}, err
+	}
+
+	return models.EventArchive{
+		Version:      models.EventArchiveVersion,
+		ExportedAt:   time.Now().UTC(),
+		Event:        event,
+		Tickets:      tickets,
+		Reservations: reservations,
+	}, nil
+}
+
+// ImportEvent restores an archived event with fresh IDs. Tickets and
+// reservations are remapped to the new IDs so their relationships survive the
+// move. The event is detached from its series, which is not part of the archive,
+// and pending transfers are dropped because their codes are never exported.
+func (e *eventService) ImportEvent(ctx context.Context, archive models.EventArchive) (models.Event, error) {
+	ctx, span := tracing.Start(ctx, "EventService.ImportEvent")
+	defer span.End()
+
+	if archive.Version != models.EventArchiveVersion {
+		return models.Event{}, ErrUnsupportedArchiveVersion
+	}
+
+	event := archive.Event
+	event.ID = bson.ObjectID{}
+	event.SeriesID = bson.ObjectID{}
+	event.DistanceKm = 0
+	event.Status = event.EffectiveStatus()
+
+	err := localizeEvent(&event)
+	if err != nil {
+		return models.Event{}, ErrInvalidArchive
+	}
+
+	tickets := make([]models.Ticket, len(archive.Tickets))
+	ticketIndex := make(map[bson.ObjectID]int, len(archive.Tickets))
+	for i, ticket := range archive.Tickets {
+		if ticket.ID.IsZero() || ticket.EventID != archive.Event.ID {
+			return models.Event{}, ErrInvalidArchive
+		}
+		if _, ok := ticketIndex[ticket.ID]; ok {
+			return models.Event{}, ErrInvalidArchive
+		}
+
+		ticketIndex[ticket.ID] = i
+		tickets[i] = ticket
+		tickets[i].ID = bson.NewObjectID()
+		tickets[i].Locate()
+	}
+
+	// Ticket state is derived from the reservations that still hold places
+	// rather than trusted from the archive, so that an edited archive cannot
+	// sell the same place twice.
+	held := make([]int, len(tickets))
+	reservations := make([]models.Reservation, len(archive.Reservations))
+	for i, reservation := range archive.Reservations {
+		index, ok := ticketIndex[reservation.TicketID]
+		if !ok || reservation.EventID != archive.Event.ID {
+			return models.Event{}, ErrInvalidArchive
+		}
+		ticket := tickets[index]
+
+		reservations[i] = reservation
+		reservations[i].ID = bson.NewObjectID()
+		reservations[i].TicketID = ticket.ID
+		reservations[i].PendingTransfer = nil
+		reservations[i].TicketType = models.TicketTypeSeated
+		if ticket.IsGeneralAdmission() {
+			reservations[i].TicketType = models.TicketTypeGeneralAdmission
+		}
+
+		if reservation.Status != models.ReservationStatusCancelled {
+			held[index] += reservation.Places()
+		}
+	}
+
+	for i := range tickets {
+		ticket := &tickets[i]
+		ticket.Status = models.TicketStatusAvailable
+
+		if ticket.IsGeneralAdmission() {
+			if held[i] > ticket.Capacity {
+				return models.Event{}, ErrInvalidArchive
+			}
+			ticket.Remaining = ticket.Capacity - held[i]
+			if ticket.Remaining == 0 {
+				ticket.Status = models.TicketStatusReserved
+			}
+			continue
+		}
+
+		if held[i] > 1 {
+			return models.Event{}, ErrInvalidArchive
+		}
+		if held[i] == 1 {
+			ticket.Status = models.TicketStatusReserved
+		}
+	}
+
+	tx, err := e.mongoClient.StartSession()
+	if err != nil {
+		return models.Event{}, err
+	}
+	defer tx.EndSession(ctx)
+
+	res, err := tx.WithTransaction(ctx, metrics.InstrumentTransaction("import_event", func(txCtx context.Context) (any, error) {
+		event, err := e.eventRepository.Create(txCtx, event)
+		if err != nil {
+			return models.Event{}, err
+		}
+
+		if len(tickets) > 0 {
+			for i := range tickets {
+				tickets[i].EventID = event.ID
+			}
+
+			_, err = e.ticketRepository.CreateMany(txCtx, tickets)
+			if err != nil {
+				return models.Event{}, err
+			}
+		}
+
+		if len(reservations) > 0 {
+			for i := range reservations {
+				reservations[i].EventID = event.ID
+			}
+
+			_, err = e.reservationRepository.CreateMany(txCtx, reservations)
+			if err != nil {
+				return models.Event{}, err
+			}
+		}
+
+		err = refreshSalesStatus(txCtx, e.eventRepository, e.ticketRepository, event.ID)
+		if err != nil {
+			return models.Event{}, err
+		}
+
+		return e.eventRepository.FindOneByID(txCtx, event.ID)
+	}))
+	if err != nil {
+		return models.Event{}, err
+	}
+
+	return res.(models.Event), nil
+}
+
+// localizeEvent normalizes the event date to UTC and records its wall-clock time
+// in the event's timezone, which is used for calendar-day filtering.
+func localizeEvent(event *models.Event) error {
+	if event.Timezone == "" {
+		event.Timezone = time.UTC.String()
+	}
+
+	loc, err := time.LoadLocation(event.Timezone)
+	if err != nil {
+		return err
+	}
+
+	event.Date = event.Date.UTC()
+	event.LocalDate = event.Date.In(loc).Format(time.RFC3339)
+	return nil
+}
+
+func refreshSalesStatus(ctx context.Context, eventRepository repositories.EventRepository, ticketRepository repositories.TicketRepository, eventID bson.ObjectID) error {
+	available, err := ticketRepository.Count(ctx, models.Ticket{
+		EventID: eventID,
+		Status:  models.TicketStatusAvailable,
+	})
+	if err != nil {
+		return err
+	}
+
+	total, err := ticketRepository.Count(ctx, models.Ticket{
+		EventID: eventID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if available == 0 && total > 0 {
+		_, err = eventRepository.TransitionStatus(ctx, eventID, []models.EventStatus{models.EventStatusOnSale}, models.EventStatusSoldOut)
+	} else if available > 0 {
+		_, err = eventRepository.TransitionStatus(ctx, eventID, []models.EventStatus{models.EventStatusSoldOut}, models.EventStatusOnSale)
+	}
 
 	return err
 }
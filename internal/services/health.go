@@ -0,0 +1,78 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/enxg/skyticket/internal/tracing"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/readpref"
+)
+
+type HealthService interface {
+	CheckReadiness(ctx context.Context) error
+	StartDraining()
+}
+
+type healthService struct {
+	mongoClient *mongo.Client
+	draining    atomic.Bool
+}
+
+const readinessTimeout = 2 * time.Second
+
+var (
+	ErrDatabaseUnavailable     = errors.New("database is unavailable")
+	ErrTransactionsUnsupported = errors.New("database does not support transactions")
+	ErrShuttingDown            = errors.New("server is shutting down")
+)
+
+func NewHealthService(mongoClient *mongo.Client) HealthService {
+	return &healthService{
+		mongoClient: mongoClient,
+	}
+}
+
+// CheckReadiness pings the primary and makes sure the deployment is a replica
+// set or a sharded cluster, since reservations cannot be made without
+// transactions.
+func (h *healthService) CheckReadiness(ctx context.Context) error {
+	ctx, span := tracing.Start(ctx, "HealthService.CheckReadiness")
+	defer span.End()
+
+	if h.draining.Load() {
+		return ErrShuttingDown
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readinessTimeout)
+	defer cancel()
+
+	err := h.mongoClient.Ping(ctx, readpref.Primary())
+	if err != nil {
+		return errors.Join(ErrDatabaseUnavailable, err)
+	}
+
+	var hello struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	err = h.mongoClient.Database("admin").RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello)
+	if err != nil {
+		return errors.Join(ErrDatabaseUnavailable, err)
+	}
+
+	if hello.SetName == "" && hello.Msg != "isdbgrid" {
+		return ErrTransactionsUnsupported
+	}
+
+	return nil
+}
+
+// StartDraining makes readiness fail from now on, so that no new traffic is
+// routed to a server that is about to shut down.
+func (h *healthService) StartDraining() {
+	h.draining.Store(true)
+}
@@ -0,0 +1,326 @@
+package services
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/enxg/skyticket/internal/models"
+	"github.com/enxg/skyticket/internal/repositories"
+	"github.com/enxg/skyticket/internal/tracing"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type QueueService interface {
+	ConfigureQueue(ctx context.Context, eventID string, enabled bool, batchSize int, intervalSeconds int) (models.EventQueue, error)
+	GetQueue(ctx context.Context, eventID string) (models.EventQueue, error)
+	JoinQueue(ctx context.Context, eventID string) (models.QueuePosition, error)
+	GetPosition(ctx context.Context, eventID string, token string) (models.QueuePosition, error)
+	CheckAdmission(ctx context.Context, eventID string, token string) error
+	AdmitDue(ctx context.Context) (int64, error)
+}
+
+type queueService struct {
+	queueRepository repositories.QueueRepository
+	eventRepository repositories.EventRepository
+	secret          []byte
+	admissionTTL    time.Duration
+}
+
+var (
+	ErrQueueNotEnabled    = errors.New("event has no enabled queue")
+	ErrQueueTokenRequired = errors.New("queue token is required")
+	ErrInvalidQueueToken  = errors.New("invalid queue token")
+	ErrNotAdmitted        = errors.New("queue token has not been admitted yet")
+	ErrAdmissionExpired   = errors.New("queue admission has expired")
+)
+
+// NewQueueService creates the queue service. Queue tokens are signed with
+// secret, which must be the same on every instance.
+func NewQueueService(queueRepository repositories.QueueRepository, eventRepository repositories.EventRepository, secret []byte, admissionTTL time.Duration) QueueService {
+	return &queueService{
+		queueRepository: queueRepository,
+		eventRepository: eventRepository,
+		secret:          secret,
+		admissionTTL:    admissionTTL,
+	}
+}
+
+func (q *queueService) ConfigureQueue(ctx context.Context, eventID string, enabled bool, batchSize int, intervalSeconds int) (models.EventQueue, error) {
+	ctx, span := tracing.Start(ctx, "QueueService.ConfigureQueue")
+	defer span.End()
+
+	eventOid, err := bson.ObjectIDFromHex(eventID)
+	if err != nil {
+		return models.EventQueue{}, err
+	}
+
+	_, err = q.eventRepository.FindOneByID(ctx, eventOid)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.EventQueue{}, ErrEventNotFound
+		}
+		return models.EventQueue{}, err
+	}
+
+	queue, err := q.queueRepository.Configure(ctx, eventOid, enabled, batchSize, intervalSeconds)
+	if err != nil {
+		return models.EventQueue{}, err
+	}
+
+	queue.Waiting, err = q.queueRepository.CountWaiting(ctx, eventOid)
+	return queue, err
+}
+
+func (q *queueService) GetQueue(ctx context.Context, eventID string) (models.EventQueue, error) {
+	ctx, span := tracing.Start(ctx, "QueueService.GetQueue")
+	defer span.End()
+
+	eventOid, err := bson.ObjectIDFromHex(eventID)
+	if err != nil {
+		return models.EventQueue{}, err
+	}
+
+	queue, err := q.queueRepository.FindByEventID(ctx, eventOid)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.EventQueue{}, ErrQueueNotEnabled
+		}
+		return models.EventQueue{}, err
+	}
+
+	queue.Waiting, err = q.queueRepository.CountWaiting(ctx, eventOid)
+	return queue, err
+}
+
+func (q *queueService) JoinQueue(ctx context.Context, eventID string) (models.QueuePosition, error) {
+	ctx, span := tracing.Start(ctx, "QueueService.JoinQueue")
+	defer span.End()
+
+	eventOid, err := bson.ObjectIDFromHex(eventID)
+	if err != nil {
+		return models.QueuePosition{}, err
+	}
+
+	event, err := q.eventRepository.FindOneByID(ctx, eventOid)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.QueuePosition{}, ErrEventNotFound
+		}
+		return models.QueuePosition{}, err
+	}
+
+	if time.Now().After(event.Date) {
+		return models.QueuePosition{}, ErrEventAlreadyPassed
+	}
+
+	if event.Status == models.EventStatusCancelled {
+		return models.QueuePosition{}, ErrEventCancelled
+	}
+
+	position, err := q.queueRepository.NextPosition(ctx, eventOid)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.QueuePosition{}, ErrQueueNotEnabled
+		}
+		return models.QueuePosition{}, err
+	}
+
+	entry, err := q.queueRepository.CreateEntry(ctx, models.QueueEntry{
+		EventID:  eventOid,
+		Position: position,
+		Status:   models.QueueEntryStatusWaiting,
+		JoinedAt: time.Now(),
+	})
+	if err != nil {
+		return models.QueuePosition{}, err
+	}
+
+	queue, err := q.queueRepository.FindByEventID(ctx, eventOid)
+	if err != nil {
+		return models.QueuePosition{}, err
+	}
+
+	entry, err = q.admitIfDue(ctx, queue, entry)
+	if err != nil {
+		return models.QueuePosition{}, err
+	}
+
+	result := queuePosition(queue, entry)
+	result.Token = q.signToken(eventOid, entry.ID)
+
+	return result, nil
+}
+
+func (q *queueService) GetPosition(ctx context.Context, eventID string, token string) (models.QueuePosition, error) {
+	ctx, span := tracing.Start(ctx, "QueueService.GetPosition")
+	defer span.End()
+
+	queue, entry, err := q.findEntry(ctx, eventID, token)
+	if err != nil {
+		return models.QueuePosition{}, err
+	}
+
+	return queuePosition(queue, entry), nil
+}
+
+// CheckAdmission allows the request when the event has no enabled queue or
+// the token belongs to an entry that has been admitted and not expired.
+func (q *queueService) CheckAdmission(ctx context.Context, eventID string, token string) error {
+	ctx, span := tracing.Start(ctx, "QueueService.CheckAdmission")
+	defer span.End()
+
+	eventOid, err := bson.ObjectIDFromHex(eventID)
+	if err != nil {
+		return err
+	}
+
+	queue, err := q.queueRepository.FindByEventID(ctx, eventOid)
+	if errors.Is(err, mongo.ErrNoDocuments) || (err == nil && !queue.Enabled) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if token == "" {
+		return ErrQueueTokenRequired
+	}
+
+	_, entry, err := q.findEntry(ctx, eventID, token)
+	if err != nil {
+		return err
+	}
+
+	if entry.Status != models.QueueEntryStatusAdmitted {
+		return ErrNotAdmitted
+	}
+
+	if entry.ExpiresAt != nil && time.Now().After(*entry.ExpiresAt) {
+		return ErrAdmissionExpired
+	}
+
+	return nil
+}
+
+func (q *queueService) AdmitDue(ctx context.Context) (int64, error) {
+	ctx, span := tracing.Start(ctx, "QueueService.AdmitDue")
+	defer span.End()
+
+	return q.queueRepository.AdmitDue(ctx, time.Now(), q.admissionTTL)
+}
+
+func (q *queueService) findEntry(ctx context.Context, eventID string, token string) (models.EventQueue, models.QueueEntry, error) {
+	eventOid, err := bson.ObjectIDFromHex(eventID)
+	if err != nil {
+		return models.EventQueue{}, models.QueueEntry{}, err
+	}
+
+	entryOid, err := q.parseToken(eventOid, token)
+	if err != nil {
+		return models.EventQueue{}, models.QueueEntry{}, err
+	}
+
+	queue, err := q.queueRepository.FindByEventID(ctx, eventOid)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.EventQueue{}, models.QueueEntry{}, ErrQueueNotEnabled
+		}
+		return models.EventQueue{}, models.QueueEntry{}, err
+	}
+
+	entry, err := q.queueRepository.FindEntry(ctx, eventOid, entryOid)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.EventQueue{}, models.QueueEntry{}, ErrInvalidQueueToken
+		}
+		return models.EventQueue{}, models.QueueEntry{}, err
+	}
+
+	entry, err = q.admitIfDue(ctx, queue, entry)
+	return queue, entry, err
+}
+
+// admitIfDue admits an entry whose position has already been reached but that
+// was missed by the batch, which happens when it was inserted while the batch
+// was being admitted.
+func (q *queueService) admitIfDue(ctx context.Context, queue models.EventQueue, entry models.QueueEntry) (models.QueueEntry, error) {
+	if entry.Status != models.QueueEntryStatusWaiting || entry.Position > queue.AdmittedUpTo {
+		return entry, nil
+	}
+
+	admitted, err := q.queueRepository.AdmitEntry(ctx, entry.ID, time.Now(), q.admissionTTL)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		// Admitted by a batch in the meantime.
+		return q.queueRepository.FindEntry(ctx, entry.EventID, entry.ID)
+	}
+
+	return admitted, err
+}
+
+// signToken encodes the entry and event IDs with an HMAC so that queue
+// positions cannot be guessed from the sequential object IDs.
+func (q *queueService) signToken(eventID bson.ObjectID, entryID bson.ObjectID) string {
+	payload := append(entryID[:], eventID[:]...)
+
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(q.sign(payload))
+}
+
+func (q *queueService) parseToken(eventID bson.ObjectID, token string) (bson.ObjectID, error) {
+	encodedPayload, encodedSignature, ok := strings.Cut(token, ".")
+	if !ok {
+		return bson.ObjectID{}, ErrInvalidQueueToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil || len(payload) != 2*len(bson.ObjectID{}) {
+		return bson.ObjectID{}, ErrInvalidQueueToken
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil || !hmac.Equal(signature, q.sign(payload)) {
+		return bson.ObjectID{}, ErrInvalidQueueToken
+	}
+
+	var entryID, tokenEventID bson.ObjectID
+	copy(entryID[:], payload[:len(entryID)])
+	copy(tokenEventID[:], payload[len(entryID):])
+
+	if tokenEventID != eventID {
+		return bson.ObjectID{}, ErrInvalidQueueToken
+	}
+
+	return entryID, nil
+}
+
+func (q *queueService) sign(payload []byte) []byte {
+	mac := hmac.New(sha256.New, q.secret)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+func queuePosition(queue models.EventQueue, entry models.QueueEntry) models.QueuePosition {
+	position := models.QueuePosition{
+		EventID:   entry.EventID,
+		Position:  entry.Position,
+		Status:    entry.Status,
+		ExpiresAt: entry.ExpiresAt,
+	}
+
+	if entry.Status != models.QueueEntryStatusWaiting {
+		return position
+	}
+
+	position.Ahead = max(0, entry.Position-queue.AdmittedUpTo-1)
+
+	batches := int((position.Ahead + int64(queue.BatchSize)) / int64(queue.BatchSize))
+	untilNext := max(0, int(time.Until(queue.NextAdmissionAt).Seconds()))
+	position.EstimatedWaitSeconds = untilNext + (batches-1)*queue.IntervalSeconds
+
+	return position
+}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"iter"
+	"math"
+	"time"
+
+	"github.com/enxg/skyticket/internal/models"
+	"github.com/enxg/skyticket/internal/repositories"
+	"github.com/enxg/skyticket/internal/tracing"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type ReportService interface {
+	GetEventStats(ctx context.Context, eventID string) (models.EventStats, error)
+	GetSalesSummary(ctx context.Context, from time.Time, to time.Time) (models.SalesSummary, error)
+	ExportManifest(ctx context.Context, eventID string, sortBy string) (Manifest, error)
+}
+
+// Manifest is an open attendee manifest. Entries holds a database cursor
+// until it has been iterated to the end.
+type Manifest struct {
+	Event    models.Event
+	Location *time.Location
+	Entries  iter.Seq2[models.ManifestEntry, error]
+}
+
+type reportService struct {
+	eventRepository       repositories.EventRepository
+	ticketRepository      repositories.TicketRepository
+	reservationRepository repositories.ReservationRepository
+}
+
+var ErrInvalidDateRange = errors.New("invalid date range")
+
+const reportDayLayout = "2006-01-02"
+
+func NewReportService(eventRepository repositories.EventRepository, ticketRepository repositories.TicketRepository, reservationRepository repositories.ReservationRepository) ReportService {
+	return &reportService{
+		eventRepository:       eventRepository,
+		ticketRepository:      ticketRepository,
+		reservationRepository: reservationRepository,
+	}
+}
+
+func (r *reportService) GetEventStats(ctx context.Context, eventID string) (models.EventStats, error) {
+	ctx, span := tracing.Start(ctx, "ReportService.GetEventStats")
+	defer span.End()
+
+	eventOid, err := bson.ObjectIDFromHex(eventID)
+	if err != nil {
+		return models.EventStats{}, err
+	}
+
+	event, err := r.eventRepository.FindOneByID(ctx, eventOid)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.EventStats{}, ErrEventNotFound
+		}
+		return models.EventStats{}, err
+	}
+
+	ticketStats, err := r.ticketRepository.StatusStats(ctx, eventOid)
+	if err != nil {
+		return models.EventStats{}, err
+	}
+
+	timezone := event.Timezone
+	if timezone == "" {
+		timezone = time.UTC.String()
+	}
+
+	daily, err := r.reservationRepository.DailyStats(ctx, eventOid, timezone)
+	if err != nil {
+		return models.EventStats{}, err
+	}
+
+	stats := models.EventStats{
+		EventID: eventOid,
+		TicketsByStatus: map[models.TicketStatus]int64{
+			models.TicketStatusAvailable: 0,
+			models.TicketStatusReserved:  0,
+		},
+		ReservationsPerDay: daily,
+	}
+
+	for _, s := range ticketStats {
+		stats.TotalTickets += s.Count
+		stats.TicketsByStatus[s.Status] = s.Count
+		if s.Status == models.TicketStatusReserved {
+			stats.GrossRevenue = s.Revenue
+		}
+	}
+
+	sold := stats.TicketsByStatus[models.TicketStatusReserved]
+	if sold > 0 {
+		stats.AveragePrice = stats.GrossRevenue / sold
+	}
+	stats.SellThrough = percentage(sold, stats.TotalTickets)
+
+	for _, day := range daily {
+		stats.Cancellations += day.Cancellations
+	}
+
+	return stats, nil
+}
+
+func (r *reportService) GetSalesSummary(ctx context.Context, from time.Time, to time.Time) (models.SalesSummary, error) {
+	ctx, span := tracing.Start(ctx, "ReportService.GetSalesSummary")
+	defer span.End()
+
+	if to.Before(from) {
+		return models.SalesSummary{}, ErrInvalidDateRange
+	}
+
+	breakdown, err := r.eventRepository.SalesSummary(ctx, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		return models.SalesSummary{}, err
+	}
+
+	summary := models.SalesSummary{
+		From:      from.Format(reportDayLayout),
+		To:        to.Format(reportDayLayout),
+		Events:    int64(len(breakdown)),
+		Breakdown: breakdown,
+	}
+
+	for _, event := range breakdown {
+		summary.TotalTickets += event.TotalTickets
+		summary.TicketsSold += event.TicketsSold
+		summary.GrossRevenue += event.GrossRevenue
+		summary.Cancellations += event.Cancellations
+	}
+	summary.SellThrough = percentage(summary.TicketsSold, summary.TotalTickets)
+
+	return summary, nil
+}
+
+func (r *reportService) ExportManifest(ctx context.Context, eventID string, sortBy string) (Manifest, error) {
+	ctx, span := tracing.Start(ctx, "ReportService.ExportManifest")
+	defer span.End()
+
+	eventOid, err := bson.ObjectIDFromHex(eventID)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	event, err := r.eventRepository.FindOneByID(ctx, eventOid)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return Manifest{}, ErrEventNotFound
+		}
+		return Manifest{}, err
+	}
+
+	// Everything that can fail is done before the cursor is opened, as only
+	// iterating the entries closes it.
+	loc, err := time.LoadLocation(event.Timezone)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	sort := repositories.ManifestSortSeat
+	if sortBy != "" {
+		sort = repositories.ManifestSort(sortBy)
+	}
+
+	entries, err := r.reservationRepository.StreamManifest(ctx, eventOid, sort)
+	if err != nil {
+		return Manifest{}, err
+	}
+
+	return Manifest{Event: event, Location: loc, Entries: entries}, nil
+}
+
+func percentage(part int64, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+
+	return math.Round(float64(part)/float64(total)*10000) / 100
+}
@@ -0,0 +1,353 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/enxg/skyticket/internal/logging"
+	"github.com/enxg/skyticket/internal/metrics"
+	"github.com/enxg/skyticket/internal/models"
+	"github.com/enxg/skyticket/internal/repositories"
+	"github.com/enxg/skyticket/internal/tracing"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type ResaleService interface {
+	CreateListing(ctx context.Context, eventID string, ticketID string, token string, price int) (models.Listing, error)
+	GetListing(ctx context.Context, eventID string, listingID string) (models.Listing, error)
+	GetListingsByEvent(ctx context.Context, eventID string) ([]models.Listing, error)
+	CancelListing(ctx context.Context, eventID string, listingID string, token string) error
+	PurchaseListing(ctx context.Context, eventID string, listingID string, buyerName string) (models.Reservation, error)
+	GetPayoutsByEvent(ctx context.Context, eventID string) ([]models.Payout, error)
+}
+
+type resaleService struct {
+	listingRepository     repositories.ListingRepository
+	payoutRepository      repositories.PayoutRepository
+	reservationRepository repositories.ReservationRepository
+	ticketRepository      repositories.TicketRepository
+	eventRepository       repositories.EventRepository
+	mongoClient           *mongo.Client
+}
+
+var (
+	ErrResalePriceTooHigh     = errors.New("resale price exceeds the event's price cap")
+	ErrTicketAlreadyListed    = errors.New("ticket is already listed for resale")
+	ErrListingUnavailable     = errors.New("listing is no longer available")
+	ErrGeneralAdmissionResale = errors.New("general admission tickets cannot be resold")
+)
+
+func NewResaleService(listingRepository repositories.ListingRepository, payoutRepository repositories.PayoutRepository, reservationRepository repositories.ReservationRepository, ticketRepository repositories.TicketRepository, eventRepository repositories.EventRepository, mongoClient *mongo.Client) ResaleService {
+	return &resaleService{
+		listingRepository:     listingRepository,
+		payoutRepository:      payoutRepository,
+		reservationRepository: reservationRepository,
+		ticketRepository:      ticketRepository,
+		eventRepository:       eventRepository,
+		mongoClient:           mongoClient,
+	}
+}
+
+func (r *resaleService) CreateListing(ctx context.Context, eventID string, ticketID string, token string, price int) (models.Listing, error) {
+	ctx, span := tracing.Start(ctx, "ResaleService.CreateListing")
+	defer span.End()
+
+	eventOid, err := bson.ObjectIDFromHex(eventID)
+	if err != nil {
+		return models.Listing{}, err
+	}
+
+	ticketOid, err := bson.ObjectIDFromHex(ticketID)
+	if err != nil {
+		return models.Listing{}, err
+	}
+
+	event, err := r.eventRepository.FindOneByID(ctx, eventOid)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.Listing{}, ErrEventNotFound
+		}
+		return models.Listing{}, err
+	}
+
+	ti := time.Now()
+
+	if event.Date.Before(ti) {
+		return models.Listing{}, ErrEventAlreadyPassed
+	}
+
+	if status := event.EffectiveStatus(); status != models.EventStatusOnSale && status != models.EventStatusSoldOut {
+		return models.Listing{}, ErrEventNotOnSale
+	}
+
+	ticket, err := r.ticketRepository.FindOne(ctx, models.Ticket{
+		ID:      ticketOid,
+		EventID: eventOid,
+	})
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.Listing{}, ErrTicketNotFound
+		}
+		return models.Listing{}, err
+	}
+
+	if ticket.IsGeneralAdmission() {
+		return models.Listing{}, ErrGeneralAdmissionResale
+	}
+
+	reservation, err := r.reservationRepository.FindCurrent(ctx, eventOid, ticketOid, "")
+	if err != nil {
+		return models.Listing{}, err
+	}
+
+	if reservation.Token != token {
+		return models.Listing{}, ErrInvalidTicketToken
+	}
+
+	if reservation.Status != models.ReservationStatusActive {
+		return models.Listing{}, ErrReservationNotActive
+	}
+
+	if price > ticket.Price*event.EffectiveResalePriceCap()/100 {
+		return models.Listing{}, ErrResalePriceTooHigh
+	}
+
+	_, err = r.listingRepository.FindOne(ctx, models.Listing{
+		TicketID: ticketOid,
+		Status:   models.ListingStatusActive,
+	})
+	if err == nil {
+		return models.Listing{}, ErrTicketAlreadyListed
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return models.Listing{}, err
+	}
+
+	listing, err := r.listingRepository.Create(ctx, models.Listing{
+		EventID:       eventOid,
+		TicketID:      ticketOid,
+		ReservationID: reservation.ID,
+		SellerName:    reservation.CustomerName,
+		SellerToken:   reservation.Token,
+		Price:         price,
+		Status:        models.ListingStatusActive,
+		CreatedAt:     ti,
+	})
+	if err != nil {
+		// A concurrent request listed the ticket after the check above.
+		if mongo.IsDuplicateKeyError(err) {
+			return models.Listing{}, ErrTicketAlreadyListed
+		}
+		return models.Listing{}, err
+	}
+
+	return listing, nil
+}
+
+func (r *resaleService) GetListing(ctx context.Context, eventID string, listingID string) (models.Listing, error) {
+	ctx, span := tracing.Start(ctx, "ResaleService.GetListing")
+	defer span.End()
+
+	eventOid, err := bson.ObjectIDFromHex(eventID)
+	if err != nil {
+		return models.Listing{}, err
+	}
+
+	oid, err := bson.ObjectIDFromHex(listingID)
+	if err != nil {
+		return models.Listing{}, err
+	}
+
+	return r.listingRepository.FindOne(ctx, models.Listing{
+		ID:      oid,
+		EventID: eventOid,
+	})
+}
+
+func (r *resaleService) GetListingsByEvent(ctx context.Context, eventID string) ([]models.Listing, error) {
+	ctx, span := tracing.Start(ctx, "ResaleService.GetListingsByEvent")
+	defer span.End()
+
+	eventOid, err := bson.ObjectIDFromHex(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = r.eventRepository.FindOneByID(ctx, eventOid)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrEventNotFound
+		}
+		return nil, err
+	}
+
+	return r.listingRepository.Find(ctx, models.Listing{
+		EventID: eventOid,
+		Status:  models.ListingStatusActive,
+	})
+}
+
+func (r *resaleService) CancelListing(ctx context.Context, eventID string, listingID string, token string) error {
+	ctx, span := tracing.Start(ctx, "ResaleService.CancelListing")
+	defer span.End()
+
+	eventOid, err := bson.ObjectIDFromHex(eventID)
+	if err != nil {
+		return err
+	}
+
+	oid, err := bson.ObjectIDFromHex(listingID)
+	if err != nil {
+		return err
+	}
+
+	listing, err := r.listingRepository.FindOne(ctx, models.Listing{
+		ID:      oid,
+		EventID: eventOid,
+	})
+	if err != nil {
+		return err
+	}
+
+	if listing.SellerToken != token {
+		return ErrInvalidTicketToken
+	}
+
+	ok, err := r.listingRepository.AttemptToCancel(ctx, oid, token)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return ErrListingUnavailable
+	}
+
+	return nil
+}
+
+func (r *resaleService) PurchaseListing(ctx context.Context, eventID string, listingID string, buyerName string) (models.Reservation, error) {
+	ctx, span := tracing.Start(ctx, "ResaleService.PurchaseListing")
+	defer span.End()
+
+	eventOid, err := bson.ObjectIDFromHex(eventID)
+	if err != nil {
+		return models.Reservation{}, err
+	}
+
+	oid, err := bson.ObjectIDFromHex(listingID)
+	if err != nil {
+		return models.Reservation{}, err
+	}
+
+	event, err := r.eventRepository.FindOneByID(ctx, eventOid)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.Reservation{}, ErrEventNotFound
+		}
+		return models.Reservation{}, err
+	}
+
+	ti := time.Now()
+
+	if event.Date.Before(ti) {
+		return models.Reservation{}, ErrEventAlreadyPassed
+	}
+
+	if status := event.EffectiveStatus(); status != models.EventStatusOnSale && status != models.EventStatusSoldOut {
+		return models.Reservation{}, ErrEventNotOnSale
+	}
+
+	listing, err := r.listingRepository.FindOne(ctx, models.Listing{
+		ID:      oid,
+		EventID: eventOid,
+	})
+	if err != nil {
+		return models.Reservation{}, err
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return models.Reservation{}, err
+	}
+
+	tx, err := r.mongoClient.StartSession()
+	if err != nil {
+		return models.Reservation{}, err
+	}
+	defer tx.EndSession(ctx)
+
+	reservation, err := tx.WithTransaction(ctx, metrics.InstrumentTransaction("purchase_listing", func(txCtx context.Context) (any, error) {
+		sold, err := r.listingRepository.AttemptToSell(txCtx, listing.ID, buyerName, ti)
+		if err != nil {
+			return models.Reservation{}, err
+		}
+
+		if !sold {
+			return models.Reservation{}, ErrListingUnavailable
+		}
+
+		reservation, err := r.reservationRepository.Reassign(txCtx, listing.ReservationID, listing.SellerToken, token, models.TransferRecord{
+			FromCustomerName: listing.SellerName,
+			ToCustomerName:   buyerName,
+			TransferredAt:    ti,
+			Method:           models.TransferMethodResale,
+		})
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return models.Reservation{}, ErrListingUnavailable
+			}
+			return models.Reservation{}, err
+		}
+
+		_, err = r.payoutRepository.Create(txCtx, models.Payout{
+			EventID:    listing.EventID,
+			ListingID:  listing.ID,
+			SellerName: listing.SellerName,
+			Amount:     listing.Price,
+			Status:     models.PayoutStatusPending,
+			CreatedAt:  ti,
+		})
+		if err != nil {
+			return models.Reservation{}, err
+		}
+
+		return reservation, nil
+	}))
+	if err != nil {
+		return models.Reservation{}, err
+	}
+
+	metrics.Revenue.WithLabelValues("resale").Add(float64(listing.Price))
+
+	logging.FromContext(ctx).Info().
+		Str("event_id", eventID).
+		Str("listing_id", listingID).
+		Str("customer_name", logging.RedactName(buyerName)).
+		Msg("listing purchased")
+
+	return reservation.(models.Reservation), nil
+}
+
+func (r *resaleService) GetPayoutsByEvent(ctx context.Context, eventID string) ([]models.Payout, error) {
+	ctx, span := tracing.Start(ctx, "ResaleService.GetPayoutsByEvent")
+	defer span.End()
+
+	eventOid, err := bson.ObjectIDFromHex(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = r.eventRepository.FindOneByID(ctx, eventOid)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, ErrEventNotFound
+		}
+		return nil, err
+	}
+
+	return r.payoutRepository.Find(ctx, models.Payout{
+		EventID: eventOid,
+	})
+}
@@ -5,41 +5,67 @@ import (
 	"errors"
 	"time"
 
+	"github.com/enxg/skyticket/internal/logging"
+	"github.com/enxg/skyticket/internal/metrics"
 	"github.com/enxg/skyticket/internal/models"
 	"github.com/enxg/skyticket/internal/repositories"
+	"github.com/enxg/skyticket/internal/tracing"
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 )
 
 type ReservationService interface {
-	CreateReservation(ctx context.Context, eventID string, ticketID string, customerName string) (models.Reservation, error)
-	GetReservation(ctx context.Context, reservationID string, ticketID string) (models.Reservation, error)
-	UpdateReservation(ctx context.Context, reservationID string, ticketID string, customerName string) (models.Reservation, error)
-	CancelReservation(ctx context.Context, reservationID string, ticketID string) error
+	CreateReservation(ctx context.Context, eventID string, ticketID string, customerName string, quantity int) (models.Reservation, error)
+	ReserveBestAvailable(ctx context.Context, eventID string, customerName string, quantity int, maxPrice int, section string) (models.BestAvailableReservation, error)
+	GetReservation(ctx context.Context, eventID string, ticketID string, token string) (models.Reservation, error)
+	CancelReservation(ctx context.Context, eventID string, ticketID string, token string) error
+	StartTransfer(ctx context.Context, eventID string, ticketID string, token string, recipientName string) (models.PendingTransfer, error)
+	AcceptTransfer(ctx context.Context, eventID string, ticketID string, code string) (models.Reservation, error)
+	ExpireReservations(ctx context.Context) (int64, error)
 }
 
 type reservationService struct {
 	reservationRepository repositories.ReservationRepository
 	ticketRepository      repositories.TicketRepository
 	eventRepository       repositories.EventRepository
+	listingRepository     repositories.ListingRepository
 	mongoClient           *mongo.Client
+	transferCodeTTL       time.Duration
 }
 
 var (
 	ErrTicketNotFound        = errors.New("ticket not found")
 	ErrTicketAlreadyReserved = errors.New("ticket already reserved")
+	ErrReservationCheckedIn  = errors.New("reservation has already been checked in")
+	ErrInvalidTransferCode   = errors.New("invalid or expired transfer code")
+	ErrNotEnoughTickets      = errors.New("not enough available tickets")
+	ErrSingleSeatGap         = errors.New("reservation would leave a single empty seat")
+	ErrNotEnoughPlaces       = errors.New("not enough general admission places remaining")
+	ErrQuantityNotAllowed    = errors.New("seated tickets can only be reserved one at a time")
+	ErrTokenRequired         = errors.New("reservation token is required")
 )
 
-func NewReservationService(reservationRepository repositories.ReservationRepository, ticketRepository repositories.TicketRepository, eventRepository repositories.EventRepository, mongoClient *mongo.Client) ReservationService {
+// bestAvailableAttempts bounds how often seats are picked again after other
+// customers reserved some of them first.
+const bestAvailableAttempts = 3
+
+func NewReservationService(reservationRepository repositories.ReservationRepository, ticketRepository repositories.TicketRepository, eventRepository repositories.EventRepository, listingRepository repositories.ListingRepository, mongoClient *mongo.Client, transferCodeTTL time.Duration) ReservationService {
 	return &reservationService{
 		reservationRepository: reservationRepository,
 		ticketRepository:      ticketRepository,
 		eventRepository:       eventRepository,
+		listingRepository:     listingRepository,
 		mongoClient:           mongoClient,
+		transferCodeTTL:       transferCodeTTL,
 	}
 }
 
-func (r *reservationService) CreateReservation(ctx context.Context, eventID string, ticketID string, customerName string) (models.Reservation, error) {
+// CreateReservation reserves a seated ticket, or quantity places of a general
+// admission ticket.
+func (r *reservationService) CreateReservation(ctx context.Context, eventID string, ticketID string, customerName string, quantity int) (models.Reservation, error) {
+	ctx, span := tracing.Start(ctx, "ReservationService.CreateReservation")
+	defer span.End()
+
 	eventOid, err := bson.ObjectIDFromHex(eventID)
 	if err != nil {
 		return models.Reservation{}, err
@@ -61,13 +87,43 @@ func (r *reservationService) CreateReservation(ctx context.Context, eventID stri
 		return models.Reservation{}, ErrEventAlreadyPassed
 	}
 
+	if event.EffectiveStatus() != models.EventStatusOnSale {
+		return models.Reservation{}, ErrEventNotOnSale
+	}
+
+	ticket, err := r.ticketRepository.FindOne(ctx, models.Ticket{
+		ID:      ticketOid,
+		EventID: event.ID,
+	})
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.Reservation{}, ErrTicketNotFound
+		}
+		return models.Reservation{}, err
+	}
+
+	quantity = max(quantity, 1)
+	if !ticket.IsGeneralAdmission() && quantity > 1 {
+		return models.Reservation{}, ErrQuantityNotAllowed
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return models.Reservation{}, err
+	}
+
 	tx, err := r.mongoClient.StartSession()
 	if err != nil {
 		return models.Reservation{}, err
 	}
 	defer tx.EndSession(ctx)
 
-	reservation, err := tx.WithTransaction(ctx, func(txCtx context.Context) (interface{}, error) {
+	var price int
+	reservation, err := tx.WithTransaction(ctx, metrics.InstrumentTransaction("create_reservation", func(txCtx context.Context) (interface{}, error) {
+		if ticket.IsGeneralAdmission() {
+			return r.reserveGeneralAdmission(txCtx, event.ID, ticketOid, customerName, quantity, token, ti, &price)
+		}
+
 		reserveTicket, err := r.ticketRepository.AttemptToReserve(txCtx, event.ID, ticketOid)
 		if err != nil {
 			return models.Reservation{}, err
@@ -78,64 +134,296 @@ func (r *reservationService) CreateReservation(ctx context.Context, eventID stri
 		} else if !reserveTicket.Reserved {
 			return models.Reservation{}, ErrTicketAlreadyReserved
 		}
+		price = reserveTicket.Price
+
+		if event.NoSingleGaps() {
+			err = r.checkSingleGaps(txCtx, reserveTicket.Ticket)
+			if err != nil {
+				return models.Reservation{}, err
+			}
+		}
 
-		return r.reservationRepository.Create(txCtx, models.Reservation{
+		reservation, err := r.reservationRepository.Create(txCtx, models.Reservation{
 			TicketID:        ticketOid,
 			EventID:         event.ID,
 			CustomerName:    customerName,
 			Status:          models.ReservationStatusActive,
 			ReservationDate: ti,
+			Token:           token,
+			TicketType:      models.TicketTypeSeated,
 		})
-	})
+		if err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				return models.Reservation{}, ErrTicketAlreadyReserved
+			}
+			return models.Reservation{}, err
+		}
+
+		return reservation, refreshSalesStatus(txCtx, r.eventRepository, r.ticketRepository, event.ID)
+	}))
+	if err != nil {
+		if errors.Is(err, ErrTicketAlreadyReserved) {
+			metrics.ReservationConflicts.Inc()
+		}
+		return models.Reservation{}, err
+	}
+
+	metrics.ReservationsCreated.Inc()
+	metrics.Revenue.WithLabelValues("primary").Add(float64(price * quantity))
+
+	logging.FromContext(ctx).Info().
+		Str("event_id", eventID).
+		Str("ticket_id", ticketID).
+		Int("quantity", quantity).
+		Str("customer_name", logging.RedactName(customerName)).
+		Msg("reservation created")
 
-	return reservation.(models.Reservation), err
+	return reservation.(models.Reservation), nil
 }
 
-func (r *reservationService) GetReservation(ctx context.Context, eventID string, ticketID string) (models.Reservation, error) {
-	eventOid, err := bson.ObjectIDFromHex(eventID)
+// reserveGeneralAdmission takes places of a general admission ticket and
+// records them as one reservation. It must run in a transaction.
+func (r *reservationService) reserveGeneralAdmission(ctx context.Context, eventID bson.ObjectID, ticketID bson.ObjectID, customerName string, quantity int, token string, at time.Time, price *int) (models.Reservation, error) {
+	reserveTicket, err := r.ticketRepository.AttemptToReserveCapacity(ctx, eventID, ticketID, quantity)
 	if err != nil {
 		return models.Reservation{}, err
 	}
 
-	ticketOid, err := bson.ObjectIDFromHex(ticketID)
+	if !reserveTicket.TicketFound {
+		return models.Reservation{}, ErrTicketNotFound
+	} else if !reserveTicket.Reserved {
+		return models.Reservation{}, ErrNotEnoughPlaces
+	}
+	*price = reserveTicket.Price
+
+	reservation, err := r.reservationRepository.Create(ctx, models.Reservation{
+		TicketID:        ticketID,
+		EventID:         eventID,
+		CustomerName:    customerName,
+		Status:          models.ReservationStatusActive,
+		ReservationDate: at,
+		Token:           token,
+		TicketType:      models.TicketTypeGeneralAdmission,
+		Quantity:        quantity,
+	})
 	if err != nil {
 		return models.Reservation{}, err
 	}
 
-	return r.reservationRepository.FindOne(ctx, models.Reservation{
-		TicketID: ticketOid,
-		EventID:  eventOid,
-	})
+	return reservation, refreshSalesStatus(ctx, r.eventRepository, r.ticketRepository, eventID)
 }
 
-func (r *reservationService) UpdateReservation(ctx context.Context, eventID string, ticketID string, customerName string) (models.Reservation, error) {
+func (r *reservationService) ReserveBestAvailable(ctx context.Context, eventID string, customerName string, quantity int, maxPrice int, section string) (models.BestAvailableReservation, error) {
+	ctx, span := tracing.Start(ctx, "ReservationService.ReserveBestAvailable")
+	defer span.End()
+
 	eventOid, err := bson.ObjectIDFromHex(eventID)
 	if err != nil {
-		return models.Reservation{}, err
+		return models.BestAvailableReservation{}, err
 	}
 
-	ticketOid, err := bson.ObjectIDFromHex(ticketID)
+	event, err := r.eventRepository.FindOneByID(ctx, eventOid)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.BestAvailableReservation{}, ErrEventNotFound
+		}
+		return models.BestAvailableReservation{}, err
+	}
+
+	ti := time.Now()
+
+	if event.Date.Before(ti) {
+		return models.BestAvailableReservation{}, ErrEventAlreadyPassed
+	}
+
+	if event.EffectiveStatus() != models.EventStatusOnSale {
+		return models.BestAvailableReservation{}, ErrEventNotOnSale
+	}
+
+	tx, err := r.mongoClient.StartSession()
+	if err != nil {
+		return models.BestAvailableReservation{}, err
+	}
+	defer tx.EndSession(ctx)
+
+	var result any
+	for range bestAvailableAttempts {
+		result, err = tx.WithTransaction(ctx, metrics.InstrumentTransaction("reserve_best_available", func(txCtx context.Context) (any, error) {
+			available, err := r.ticketRepository.FindAvailable(txCtx, event.ID, maxPrice, section)
+			if err != nil {
+				return models.BestAvailableReservation{}, err
+			}
+
+			var allowed func([]models.Ticket) bool
+			if event.NoSingleGaps() {
+				all, err := r.ticketRepository.Find(txCtx, models.Ticket{
+					EventID: event.ID,
+				})
+				if err != nil {
+					return models.BestAvailableReservation{}, err
+				}
+
+				allowed = func(block []models.Ticket) bool {
+					return !leavesSingleGap(all, block)
+				}
+			}
+
+			tickets, adjacent := pickBestAvailable(available, quantity, allowed)
+			if tickets == nil {
+				if len(available) >= quantity {
+					return models.BestAvailableReservation{}, ErrSingleSeatGap
+				}
+				return models.BestAvailableReservation{}, ErrNotEnoughTickets
+			}
+
+			ticketIDs := make([]bson.ObjectID, len(tickets))
+			for i, ticket := range tickets {
+				ticketIDs[i] = ticket.ID
+			}
+
+			// The tickets were read in the same transaction, so this only
+			// falls short if another reservation committed in between.
+			reserved, err := r.ticketRepository.ReserveMany(txCtx, event.ID, ticketIDs)
+			if err != nil {
+				return models.BestAvailableReservation{}, err
+			}
+			if reserved != int64(len(tickets)) {
+				return models.BestAvailableReservation{}, ErrTicketAlreadyReserved
+			}
+
+			reservations := make([]models.Reservation, len(tickets))
+			totalPrice := 0
+			for i, ticket := range tickets {
+				token, err := generateToken()
+				if err != nil {
+					return models.BestAvailableReservation{}, err
+				}
+
+				reservations[i] = models.Reservation{
+					TicketID:        ticket.ID,
+					EventID:         event.ID,
+					CustomerName:    customerName,
+					Status:          models.ReservationStatusActive,
+					ReservationDate: ti,
+					Token:           token,
+					TicketType:      models.TicketTypeSeated,
+				}
+				tickets[i].Status = models.TicketStatusReserved
+				totalPrice += ticket.Price
+			}
+
+			reservations, err = r.reservationRepository.CreateMany(txCtx, reservations)
+			if err != nil {
+				if mongo.IsDuplicateKeyError(err) {
+					return models.BestAvailableReservation{}, ErrTicketAlreadyReserved
+				}
+				return models.BestAvailableReservation{}, err
+			}
+
+			return models.BestAvailableReservation{
+				Adjacent:     adjacent,
+				TotalPrice:   totalPrice,
+				Tickets:      tickets,
+				Reservations: reservations,
+			}, refreshSalesStatus(txCtx, r.eventRepository, r.ticketRepository, event.ID)
+		}))
+		if !errors.Is(err, ErrTicketAlreadyReserved) {
+			break
+		}
+		metrics.ReservationConflicts.Inc()
+	}
+	if err != nil {
+		return models.BestAvailableReservation{}, err
+	}
+
+	reservation := result.(models.BestAvailableReservation)
+
+	metrics.ReservationsCreated.Add(float64(quantity))
+	metrics.Revenue.WithLabelValues("primary").Add(float64(reservation.TotalPrice))
+
+	logging.FromContext(ctx).Info().
+		Str("event_id", eventID).
+		Int("quantity", quantity).
+		Bool("adjacent", reservation.Adjacent).
+		Str("customer_name", logging.RedactName(customerName)).
+		Msg("best available tickets reserved")
+
+	return reservation, nil
+}
+
+// checkSingleGaps fails with ErrSingleSeatGap if reserving ticket left a
+// single empty seat next to it. It must run in the reserving transaction.
+func (r *reservationService) checkSingleGaps(ctx context.Context, ticket models.Ticket) error {
+	ticket.Locate()
+	if ticket.Row == "" || ticket.Seat == 0 {
+		return nil
+	}
+
+	row, err := r.ticketRepository.Find(ctx, models.Ticket{
+		EventID: ticket.EventID,
+		Row:     ticket.Row,
+	})
+	if err != nil {
+		return err
+	}
+
+	if leavesSingleGap(row, []models.Ticket{ticket}) {
+		return ErrSingleSeatGap
+	}
+
+	return nil
+}
+
+// heldReservation returns the current reservation of a ticket whose holder
+// presented its token, along with the ticket. The token doubles as the gate
+// credential, so reservations are only ever read or changed by their holder.
+// It also tells apart the reservations of a general admission ticket.
+func (r *reservationService) heldReservation(ctx context.Context, eventID bson.ObjectID, ticketID bson.ObjectID, token string) (models.Reservation, models.Ticket, error) {
+	if token == "" {
+		return models.Reservation{}, models.Ticket{}, ErrTokenRequired
+	}
+
+	reservation, err := r.reservationRepository.FindCurrent(ctx, eventID, ticketID, token)
+	if err != nil {
+		return models.Reservation{}, models.Ticket{}, err
+	}
+
+	ticket, err := r.ticketRepository.FindOne(ctx, models.Ticket{
+		ID:      ticketID,
+		EventID: eventID,
+	})
+	if err != nil {
+		return models.Reservation{}, models.Ticket{}, err
+	}
+
+	return reservation, ticket, nil
+}
+
+func (r *reservationService) GetReservation(ctx context.Context, eventID string, ticketID string, token string) (models.Reservation, error) {
+	ctx, span := tracing.Start(ctx, "ReservationService.GetReservation")
+	defer span.End()
+
+	eventOid, err := bson.ObjectIDFromHex(eventID)
 	if err != nil {
 		return models.Reservation{}, err
 	}
 
-	event, err := r.eventRepository.FindOneByID(ctx, eventOid)
+	ticketOid, err := bson.ObjectIDFromHex(ticketID)
 	if err != nil {
 		return models.Reservation{}, err
 	}
 
-	if event.Date.Before(time.Now()) {
-		return models.Reservation{}, ErrEventAlreadyPassed
+	if token == "" {
+		return models.Reservation{}, ErrTokenRequired
 	}
 
-	return r.reservationRepository.Update(ctx, models.Reservation{
-		TicketID:     ticketOid,
-		EventID:      eventOid,
-		CustomerName: customerName,
-	})
+	return r.reservationRepository.FindCurrent(ctx, eventOid, ticketOid, token)
 }
 
-func (r *reservationService) CancelReservation(ctx context.Context, eventID string, ticketID string) error {
+func (r *reservationService) CancelReservation(ctx context.Context, eventID string, ticketID string, token string) error {
+	ctx, span := tracing.Start(ctx, "ReservationService.CancelReservation")
+	defer span.End()
+
 	eventOid, err := bson.ObjectIDFromHex(eventID)
 	if err != nil {
 		return err
@@ -155,27 +443,235 @@ func (r *reservationService) CancelReservation(ctx context.Context, eventID stri
 		return ErrEventAlreadyPassed
 	}
 
+	reservation, ticket, err := r.heldReservation(ctx, eventOid, ticketOid, token)
+	if err != nil {
+		return err
+	}
+
+	if reservation.Status == models.ReservationStatusCheckedIn {
+		return ErrReservationCheckedIn
+	}
+
 	tx, err := r.mongoClient.StartSession()
 	if err != nil {
 		return err
 	}
+	defer tx.EndSession(ctx)
 
-	_, err = tx.WithTransaction(ctx, func(txCtx context.Context) (any, error) {
-		err := r.reservationRepository.Delete(txCtx, models.Reservation{
-			TicketID: ticketOid,
-			EventID:  eventOid,
-		})
+	_, err = tx.WithTransaction(ctx, metrics.InstrumentTransaction("cancel_reservation", func(txCtx context.Context) (any, error) {
+		err := r.reservationRepository.Cancel(txCtx, eventOid, ticketOid, token, time.Now())
 		if err != nil {
 			return nil, err
 		}
 
+		if ticket.IsGeneralAdmission() {
+			err = r.ticketRepository.ReleaseCapacity(txCtx, eventOid, ticketOid, reservation.Places())
+			if err != nil {
+				return nil, err
+			}
+
+			return nil, refreshSalesStatus(txCtx, r.eventRepository, r.ticketRepository, eventOid)
+		}
+
 		_, err = r.ticketRepository.Update(txCtx, models.Ticket{
 			ID:      ticketOid,
 			EventID: eventOid,
 			Status:  models.TicketStatusAvailable,
 		})
-		return nil, err
+		if err != nil {
+			return nil, err
+		}
+
+		err = r.listingRepository.DeleteMany(txCtx, models.Listing{
+			TicketID: ticketOid,
+			Status:   models.ListingStatusActive,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, refreshSalesStatus(txCtx, r.eventRepository, r.ticketRepository, eventOid)
+	}))
+	if err != nil {
+		return err
+	}
+
+	metrics.ReservationsCancelled.Inc()
+
+	logging.FromContext(ctx).Info().
+		Str("event_id", eventID).
+		Str("ticket_id", ticketID).
+		Msg("reservation cancelled")
+
+	return nil
+}
+
+func (r *reservationService) StartTransfer(ctx context.Context, eventID string, ticketID string, token string, recipientName string) (models.PendingTransfer, error) {
+	ctx, span := tracing.Start(ctx, "ReservationService.StartTransfer")
+	defer span.End()
+
+	eventOid, err := bson.ObjectIDFromHex(eventID)
+	if err != nil {
+		return models.PendingTransfer{}, err
+	}
+
+	ticketOid, err := bson.ObjectIDFromHex(ticketID)
+	if err != nil {
+		return models.PendingTransfer{}, err
+	}
+
+	event, err := r.eventRepository.FindOneByID(ctx, eventOid)
+	if err != nil {
+		return models.PendingTransfer{}, err
+	}
+
+	ti := time.Now()
+
+	if event.Date.Before(ti) {
+		return models.PendingTransfer{}, ErrEventAlreadyPassed
+	}
+
+	// General admission tickets have several current reservations, which
+	// the token tells apart, so a wrong token finds none.
+	reservation, err := r.reservationRepository.FindCurrent(ctx, eventOid, ticketOid, token)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return models.PendingTransfer{}, ErrInvalidTicketToken
+		}
+		return models.PendingTransfer{}, err
+	}
+
+	if reservation.Status != models.ReservationStatusActive {
+		return models.PendingTransfer{}, ErrReservationNotActive
+	}
+
+	_, err = r.listingRepository.FindOne(ctx, models.Listing{
+		TicketID: ticketOid,
+		Status:   models.ListingStatusActive,
+	})
+	if err == nil {
+		return models.PendingTransfer{}, ErrTicketAlreadyListed
+	}
+	if !errors.Is(err, mongo.ErrNoDocuments) {
+		return models.PendingTransfer{}, err
+	}
+
+	code, err := generateToken()
+	if err != nil {
+		return models.PendingTransfer{}, err
+	}
+
+	transfer := models.PendingTransfer{
+		RecipientName: recipientName,
+		Code:          code,
+		InitiatedAt:   ti,
+		ExpiresAt:     ti.Add(r.transferCodeTTL),
+	}
+
+	ok, err := r.reservationRepository.SetPendingTransfer(ctx, eventOid, ticketOid, token, transfer)
+	if err != nil {
+		return models.PendingTransfer{}, err
+	}
+
+	if !ok {
+		return models.PendingTransfer{}, ErrReservationNotActive
+	}
+
+	return transfer, nil
+}
+
+func (r *reservationService) AcceptTransfer(ctx context.Context, eventID string, ticketID string, code string) (models.Reservation, error) {
+	ctx, span := tracing.Start(ctx, "ReservationService.AcceptTransfer")
+	defer span.End()
+
+	eventOid, err := bson.ObjectIDFromHex(eventID)
+	if err != nil {
+		return models.Reservation{}, err
+	}
+
+	ticketOid, err := bson.ObjectIDFromHex(ticketID)
+	if err != nil {
+		return models.Reservation{}, err
+	}
+
+	event, err := r.eventRepository.FindOneByID(ctx, eventOid)
+	if err != nil {
+		return models.Reservation{}, err
+	}
+
+	ti := time.Now()
+
+	if event.Date.Before(ti) {
+		return models.Reservation{}, ErrEventAlreadyPassed
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return models.Reservation{}, err
+	}
+
+	tx, err := r.mongoClient.StartSession()
+	if err != nil {
+		return models.Reservation{}, err
+	}
+	defer tx.EndSession(ctx)
+
+	reservation, err := tx.WithTransaction(ctx, metrics.InstrumentTransaction("accept_transfer", func(txCtx context.Context) (any, error) {
+		reservation, err := r.reservationRepository.AttemptToTransfer(txCtx, eventOid, ticketOid, code, token, ti)
+		if err != nil {
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return models.Reservation{}, ErrInvalidTransferCode
+			}
+			return models.Reservation{}, err
+		}
+
+		// The previous holder's listing would otherwise stay up for sale,
+		// and the new holder could neither sell nor withdraw it.
+		err = r.listingRepository.DeleteMany(txCtx, models.Listing{
+			TicketID:      ticketOid,
+			ReservationID: reservation.ID,
+			Status:        models.ListingStatusActive,
+		})
+		if err != nil {
+			return models.Reservation{}, err
+		}
+
+		return reservation, nil
+	}))
+	if err != nil {
+		return models.Reservation{}, err
+	}
+
+	logging.FromContext(ctx).Info().
+		Str("event_id", eventID).
+		Str("ticket_id", ticketID).
+		Str("customer_name", logging.RedactName(reservation.(models.Reservation).CustomerName)).
+		Msg("reservation transferred")
+
+	return reservation.(models.Reservation), nil
+}
+
+// ExpireReservations marks active reservations of events that have already
+// taken place as expired. Checked-in reservations are left untouched.
+func (r *reservationService) ExpireReservations(ctx context.Context) (int64, error) {
+	ctx, span := tracing.Start(ctx, "ReservationService.ExpireReservations")
+	defer span.End()
+
+	events, err := r.eventRepository.List(ctx, repositories.EventQuery{
+		Before: time.Now(),
 	})
+	if err != nil {
+		return 0, err
+	}
+
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	ids := make([]bson.ObjectID, len(events))
+	for i, event := range events {
+		ids[i] = event.ID
+	}
 
-	return err
+	return r.reservationRepository.Expire(ctx, ids)
 }
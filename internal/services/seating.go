@@ -0,0 +1,219 @@
+package services
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/enxg/skyticket/internal/models"
+)
+
+type seat struct {
+	area   string
+	row    string
+	number int
+	ticket models.Ticket
+}
+
+type seatKey struct {
+	area   string
+	row    string
+	number int
+}
+
+type seatBlock struct {
+	seats []seat
+	price int
+}
+
+// newSeat places a ticket in its row. Tickets without coordinates get an
+// empty row.
+func newSeat(ticket models.Ticket) seat {
+	ticket.Locate()
+	return seat{area: ticket.Area(), row: ticket.Row, number: ticket.Seat, ticket: ticket}
+}
+
+// pickBestAvailable chooses quantity tickets out of available. It prefers
+// consecutive seats in a single row and, among those, the most expensive
+// block, which is taken to be the best one within the customer's price
+// ceiling. Ties go to the front-most row and the lowest seat numbers. When no
+// such block exists it falls back to the tightest group of seats in a single
+// row, and then to the most expensive tickets overall. Blocks rejected by
+// allowed, if set, are skipped. The second result reports whether the chosen
+// seats are adjacent.
+func pickBestAvailable(available []models.Ticket, quantity int, allowed func([]models.Ticket) bool) ([]models.Ticket, bool) {
+	if quantity <= 0 || len(available) < quantity {
+		return nil, false
+	}
+
+	if allowed == nil {
+		allowed = func([]models.Ticket) bool { return true }
+	}
+
+	seats := make([]seat, 0, len(available))
+	unnumbered := make([]seat, 0)
+	for _, ticket := range available {
+		s := newSeat(ticket)
+		if s.row != "" && s.number > 0 {
+			seats = append(seats, s)
+		} else {
+			unnumbered = append(unnumbered, s)
+		}
+	}
+
+	slices.SortFunc(seats, compareSeatPosition)
+	rows := groupRows(seats)
+
+	// Unnumbered seats have no neighbours, but still qualify on their own.
+	for _, s := range unnumbered {
+		rows = append(rows, []seat{s})
+	}
+
+	var adjacent []seatBlock
+	for _, row := range rows {
+		for i := 0; i+quantity <= len(row); i++ {
+			if consecutive(row[i:i+quantity]) && allowed(seatTickets(row[i:i+quantity])) {
+				adjacent = append(adjacent, newSeatBlock(row[i:i+quantity]))
+			}
+		}
+	}
+
+	if len(adjacent) > 0 {
+		best := slices.MinFunc(adjacent, func(a, b seatBlock) int {
+			return cmp.Or(
+				cmp.Compare(b.price, a.price),
+				compareSeatPosition(a.seats[0], b.seats[0]),
+			)
+		})
+		return seatTickets(best.seats), true
+	}
+
+	var sameRow []seatBlock
+	for _, row := range rows {
+		for i := 0; i+quantity <= len(row); i++ {
+			if allowed(seatTickets(row[i : i+quantity])) {
+				sameRow = append(sameRow, newSeatBlock(row[i:i+quantity]))
+			}
+		}
+	}
+
+	if len(sameRow) > 0 {
+		best := slices.MinFunc(sameRow, func(a, b seatBlock) int {
+			return cmp.Or(
+				cmp.Compare(span(a), span(b)),
+				cmp.Compare(b.price, a.price),
+				compareSeatPosition(a.seats[0], b.seats[0]),
+			)
+		})
+		return seatTickets(best.seats), false
+	}
+
+	all := append(seats, unnumbered...)
+	slices.SortStableFunc(all, func(a, b seat) int {
+		return cmp.Compare(b.ticket.Price, a.ticket.Price)
+	})
+
+	tickets := seatTickets(all[:quantity])
+	if !allowed(tickets) {
+		return nil, false
+	}
+
+	return tickets, false
+}
+
+// leavesSingleGap reports whether reserving chosen would leave a single empty
+// seat between two occupied seats. tickets must include every ticket in the
+// rows of the chosen ones with their current status. Gaps that already exist
+// are not counted against the reservation.
+func leavesSingleGap(tickets []models.Ticket, chosen []models.Ticket) bool {
+	occupied := make(map[seatKey]bool, len(tickets)+len(chosen))
+	for _, ticket := range tickets {
+		s := newSeat(ticket)
+		if s.row != "" && s.number > 0 {
+			occupied[s.key()] = ticket.Status != models.TicketStatusAvailable
+		}
+	}
+
+	located := make([]seat, 0, len(chosen))
+	for _, ticket := range chosen {
+		s := newSeat(ticket)
+		if s.row != "" && s.number > 0 {
+			occupied[s.key()] = true
+			located = append(located, s)
+		}
+	}
+
+	for _, s := range located {
+		for _, step := range []int{-1, 1} {
+			neighbour := s.key()
+			neighbour.number += step
+			if taken, ok := occupied[neighbour]; !ok || taken {
+				continue
+			}
+
+			beyond := neighbour
+			beyond.number += step
+			if occupied[beyond] {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (s seat) key() seatKey {
+	return seatKey{area: s.area, row: s.row, number: s.number}
+}
+
+// compareSeatPosition orders seats by area, then row from the front ("B"
+// before "AA"), then seat number.
+func compareSeatPosition(a, b seat) int {
+	return cmp.Or(
+		cmp.Compare(a.area, b.area),
+		cmp.Compare(len(a.row), len(b.row)),
+		cmp.Compare(a.row, b.row),
+		cmp.Compare(a.number, b.number),
+		cmp.Compare(a.ticket.SeatNumber, b.ticket.SeatNumber),
+	)
+}
+
+// groupRows splits sorted seats into rows of the same area.
+func groupRows(seats []seat) [][]seat {
+	var rows [][]seat
+	for i, s := range seats {
+		if i == 0 || s.area != seats[i-1].area || s.row != seats[i-1].row {
+			rows = append(rows, nil)
+		}
+		rows[len(rows)-1] = append(rows[len(rows)-1], s)
+	}
+	return rows
+}
+
+func consecutive(seats []seat) bool {
+	for i := 1; i < len(seats); i++ {
+		if seats[i].number != seats[i-1].number+1 {
+			return false
+		}
+	}
+	return true
+}
+
+func span(block seatBlock) int {
+	return block.seats[len(block.seats)-1].number - block.seats[0].number
+}
+
+func newSeatBlock(seats []seat) seatBlock {
+	block := seatBlock{seats: seats}
+	for _, s := range seats {
+		block.price += s.ticket.Price
+	}
+	return block
+}
+
+func seatTickets(seats []seat) []models.Ticket {
+	tickets := make([]models.Ticket, len(seats))
+	for i, s := range seats {
+		tickets[i] = s.ticket
+	}
+	return tickets
+}
@@ -0,0 +1,226 @@
+package services
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/enxg/skyticket/internal/models"
+)
+
+func seatTicket(seatNumber string, price int, status models.TicketStatus) models.Ticket {
+	return models.Ticket{SeatNumber: seatNumber, Price: price, Status: status}
+}
+
+func seatNumbers(tickets []models.Ticket) []string {
+	numbers := make([]string, len(tickets))
+	for i, ticket := range tickets {
+		numbers[i] = ticket.SeatNumber
+	}
+	return numbers
+}
+
+func TestLeavesSingleGap(t *testing.T) {
+	const (
+		available = models.TicketStatusAvailable
+		reserved  = models.TicketStatusReserved
+	)
+
+	// A1 and A6 are taken, A2 to A5 are free.
+	row := []models.Ticket{
+		seatTicket("A1", 100, reserved),
+		seatTicket("A2", 100, available),
+		seatTicket("A3", 100, available),
+		seatTicket("A4", 100, available),
+		seatTicket("A5", 100, available),
+		seatTicket("A6", 100, reserved),
+	}
+
+	// A2 is already stranded between A1 and A3.
+	existingGap := []models.Ticket{
+		seatTicket("A1", 100, reserved),
+		seatTicket("A2", 100, available),
+		seatTicket("A3", 100, reserved),
+		seatTicket("A4", 100, available),
+		seatTicket("A5", 100, available),
+		seatTicket("A6", 100, available),
+	}
+
+	tests := []struct {
+		name    string
+		tickets []models.Ticket
+		chosen  []string
+		want    bool
+	}{
+		{name: "next to a taken seat", tickets: row, chosen: []string{"A2"}, want: false},
+		{name: "next to a taken seat from the other side", tickets: row, chosen: []string{"A5"}, want: false},
+		{name: "one seat away from a taken seat", tickets: row, chosen: []string{"A3"}, want: true},
+		{name: "one seat away from the other taken seat", tickets: row, chosen: []string{"A4"}, want: true},
+		{name: "block filling from one end", tickets: row, chosen: []string{"A2", "A3"}, want: false},
+		{name: "block in the middle", tickets: row, chosen: []string{"A3", "A4"}, want: true},
+		{name: "block filling the row", tickets: row, chosen: []string{"A2", "A3", "A4", "A5"}, want: false},
+		{name: "existing gap is not counted", tickets: existingGap, chosen: []string{"A4"}, want: false},
+		{name: "new gap beside an existing one", tickets: existingGap, chosen: []string{"A5"}, want: true},
+		{
+			name: "end of the row is not a gap",
+			tickets: []models.Ticket{
+				seatTicket("A1", 100, available),
+				seatTicket("A2", 100, available),
+				seatTicket("A3", 100, reserved),
+			},
+			chosen: []string{"A2"},
+			want:   false,
+		},
+		{
+			name: "other rows are not neighbours",
+			tickets: []models.Ticket{
+				seatTicket("A1", 100, reserved),
+				seatTicket("B1", 100, available),
+				seatTicket("B2", 100, available),
+				seatTicket("B3", 100, available),
+			},
+			chosen: []string{"B2"},
+			want:   false,
+		},
+		{name: "unnumbered seats", tickets: row, chosen: []string{"GA"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chosen := make([]models.Ticket, len(tt.chosen))
+			for i, seatNumber := range tt.chosen {
+				chosen[i] = seatTicket(seatNumber, 100, available)
+			}
+
+			got := leavesSingleGap(tt.tickets, chosen)
+			if got != tt.want {
+				t.Fatalf("leavesSingleGap(%v) = %v, want %v", tt.chosen, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPickBestAvailable(t *testing.T) {
+	available := func(seats map[string]int) []models.Ticket {
+		tickets := make([]models.Ticket, 0, len(seats))
+		for seatNumber, price := range seats {
+			tickets = append(tickets, seatTicket(seatNumber, price, models.TicketStatusAvailable))
+		}
+		return tickets
+	}
+
+	tests := []struct {
+		name         string
+		available    []models.Ticket
+		quantity     int
+		allowed      func([]models.Ticket) bool
+		want         []string
+		wantAdjacent bool
+	}{
+		{
+			name:         "adjacent seats over a split pair",
+			available:    available(map[string]int{"A1": 100, "A3": 100, "A4": 100}),
+			quantity:     2,
+			want:         []string{"A3", "A4"},
+			wantAdjacent: true,
+		},
+		{
+			name:         "most expensive block",
+			available:    available(map[string]int{"A1": 100, "A2": 100, "C1": 200, "C2": 200}),
+			quantity:     2,
+			want:         []string{"C1", "C2"},
+			wantAdjacent: true,
+		},
+		{
+			name:         "front row breaks price ties",
+			available:    available(map[string]int{"B1": 100, "B2": 100, "A5": 100, "A6": 100}),
+			quantity:     2,
+			want:         []string{"A5", "A6"},
+			wantAdjacent: true,
+		},
+		{
+			name:         "single letter rows come before double letter rows",
+			available:    available(map[string]int{"AA1": 100, "AA2": 100, "Z1": 100, "Z2": 100}),
+			quantity:     2,
+			want:         []string{"Z1", "Z2"},
+			wantAdjacent: true,
+		},
+		{
+			name:         "lowest seat numbers break ties within a row",
+			available:    available(map[string]int{"A1": 100, "A2": 100, "A3": 100, "A4": 100}),
+			quantity:     2,
+			want:         []string{"A1", "A2"},
+			wantAdjacent: true,
+		},
+		{
+			name:         "tightest group in a single row",
+			available:    available(map[string]int{"A1": 100, "A3": 100, "B1": 200, "B5": 200}),
+			quantity:     2,
+			want:         []string{"A1", "A3"},
+			wantAdjacent: false,
+		},
+		{
+			name:         "most expensive seats across rows",
+			available:    available(map[string]int{"A1": 100, "B1": 300, "C1": 200}),
+			quantity:     2,
+			want:         []string{"B1", "C1"},
+			wantAdjacent: false,
+		},
+		{
+			name:         "unnumbered seats on their own",
+			available:    available(map[string]int{"A1": 100, "GA": 500}),
+			quantity:     1,
+			want:         []string{"GA"},
+			wantAdjacent: true,
+		},
+		{
+			name:      "rejected blocks are skipped",
+			available: available(map[string]int{"A1": 200, "A2": 200, "A3": 100}),
+			quantity:  2,
+			allowed: func(tickets []models.Ticket) bool {
+				return !slices.Contains(seatNumbers(tickets), "A1")
+			},
+			want:         []string{"A2", "A3"},
+			wantAdjacent: true,
+		},
+		{
+			name:      "every block rejected",
+			available: available(map[string]int{"A1": 100, "A2": 100, "B1": 100}),
+			quantity:  2,
+			allowed:   func([]models.Ticket) bool { return false },
+			want:      nil,
+		},
+		{
+			name:      "more seats than available",
+			available: available(map[string]int{"A1": 100, "A2": 100}),
+			quantity:  3,
+			want:      nil,
+		},
+		{
+			name:      "zero quantity",
+			available: available(map[string]int{"A1": 100}),
+			quantity:  0,
+			want:      nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, adjacent := pickBestAvailable(tt.available, tt.quantity, tt.allowed)
+
+			if tt.want == nil {
+				if got != nil {
+					t.Fatalf("pickBestAvailable() = %v, want none", seatNumbers(got))
+				}
+				return
+			}
+
+			numbers := seatNumbers(got)
+			if !slices.Equal(numbers, tt.want) {
+				t.Fatalf("pickBestAvailable() = %v, want %v", numbers, tt.want)
+			}
+			if adjacent != tt.wantAdjacent {
+				t.Fatalf("pickBestAvailable() adjacent = %v, want %v", adjacent, tt.wantAdjacent)
+			}
+		})
+	}
+}
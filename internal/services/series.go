@@ -0,0 +1,253 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/enxg/skyticket/internal/metrics"
+	"github.com/enxg/skyticket/internal/models"
+	"github.com/enxg/skyticket/internal/repositories"
+	"github.com/enxg/skyticket/internal/tracing"
+	"github.com/enxg/skyticket/pkg/rrule"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+)
+
+type SeriesService interface {
+	CreateSeries(ctx context.Context, input models.EventSeries) (models.EventSeries, error)
+	GetSeriesByID(ctx context.Context, id string) (models.EventSeries, error)
+	GetSeriesEvents(ctx context.Context, id string) ([]models.Event, error)
+	UpdateSeries(ctx context.Context, id string, name string, venue string, resalePriceCap int, address *models.Address, location *models.GeoPoint) (models.EventSeries, error)
+}
+
+type seriesService struct {
+	seriesRepository repositories.SeriesRepository
+	eventRepository  repositories.EventRepository
+	ticketRepository repositories.TicketRepository
+	mongoClient      *mongo.Client
+}
+
+const maxSeriesOccurrences = 366
+
+var (
+	ErrInvalidRecurrence  = errors.New("invalid recurrence rule")
+	ErrTooManyOccurrences = errors.New("recurrence rule produces too many events")
+	ErrNoSeriesChanges    = errors.New("no series fields to update")
+)
+
+func NewSeriesService(seriesRepository repositories.SeriesRepository, eventRepository repositories.EventRepository, ticketRepository repositories.TicketRepository, mongoClient *mongo.Client) SeriesService {
+	return &seriesService{
+		seriesRepository: seriesRepository,
+		eventRepository:  eventRepository,
+		ticketRepository: ticketRepository,
+		mongoClient:      mongoClient,
+	}
+}
+
+func (s *seriesService) CreateSeries(ctx context.Context, input models.EventSeries) (models.EventSeries, error) {
+	ctx, span := tracing.Start(ctx, "SeriesService.CreateSeries")
+	defer span.End()
+
+	rule, err := rrule.Parse(input.Recurrence)
+	if err != nil {
+		return models.EventSeries{}, ErrInvalidRecurrence
+	}
+
+	if input.Timezone == "" {
+		input.Timezone = time.UTC.String()
+	}
+
+	loc, err := time.LoadLocation(input.Timezone)
+	if err != nil {
+		return models.EventSeries{}, err
+	}
+
+	dates, err := rule.Occurrences(input.Start.In(loc), maxSeriesOccurrences)
+	if err != nil {
+		if errors.Is(err, rrule.ErrTooManyEvents) {
+			return models.EventSeries{}, ErrTooManyOccurrences
+		}
+		return models.EventSeries{}, err
+	}
+
+	if len(dates) == 0 {
+		return models.EventSeries{}, ErrInvalidRecurrence
+	}
+
+	seats := make(map[string]struct{}, len(input.Tickets))
+	for _, ticket := range input.Tickets {
+		if ticket.SeatNumber == "" {
+			continue
+		}
+		if _, ok := seats[ticket.SeatNumber]; ok {
+			return models.EventSeries{}, ErrSeatNumberTaken
+		}
+		seats[ticket.SeatNumber] = struct{}{}
+	}
+
+	input.Start = input.Start.UTC()
+
+	tx, err := s.mongoClient.StartSession()
+	if err != nil {
+		return models.EventSeries{}, err
+	}
+	defer tx.EndSession(ctx)
+
+	series, err := tx.WithTransaction(ctx, metrics.InstrumentTransaction("create_series", func(txCtx context.Context) (any, error) {
+		series, err := s.seriesRepository.Create(txCtx, input)
+		if err != nil {
+			return models.EventSeries{}, err
+		}
+
+		events := make([]models.Event, len(dates))
+		for i, date := range dates {
+			events[i] = models.Event{
+				Name:           series.Name,
+				Date:           date,
+				Timezone:       series.Timezone,
+				Venue:          series.Venue,
+				ResalePriceCap: series.ResalePriceCap,
+				Status:         models.EventStatusDraft,
+				SeriesID:       series.ID,
+				Category:       series.Category,
+				Tags:           series.Tags,
+				Address:        series.Address,
+				Location:       series.Location,
+				SeatingRules:   series.SeatingRules,
+			}
+
+			err = localizeEvent(&events[i])
+			if err != nil {
+				return models.EventSeries{}, err
+			}
+		}
+
+		events, err = s.eventRepository.CreateMany(txCtx, events)
+		if err != nil {
+			return models.EventSeries{}, err
+		}
+
+		if len(series.Tickets) == 0 {
+			return series, nil
+		}
+
+		inventory := make([]models.Ticket, 0, len(events)*len(series.Tickets))
+		for _, event := range events {
+			for _, ticket := range series.Tickets {
+				t := models.Ticket{
+					EventID:    event.ID,
+					SeatNumber: ticket.SeatNumber,
+					Price:      ticket.Price,
+					Category:   ticket.Category,
+					Section:    ticket.Section,
+					Row:        ticket.Row,
+					Seat:       ticket.Seat,
+					Status:     models.TicketStatusAvailable,
+				}
+				if ticket.Capacity > 0 {
+					t.Type = models.TicketTypeGeneralAdmission
+					t.Capacity = ticket.Capacity
+					t.Remaining = ticket.Capacity
+				}
+				t.Locate()
+				inventory = append(inventory, t)
+			}
+		}
+
+		_, err = s.ticketRepository.CreateMany(txCtx, inventory)
+		if err != nil {
+			return models.EventSeries{}, err
+		}
+
+		return series, nil
+	}))
+	if err != nil {
+		return models.EventSeries{}, err
+	}
+
+	return series.(models.EventSeries), nil
+}
+
+func (s *seriesService) GetSeriesByID(ctx context.Context, id string) (models.EventSeries, error) {
+	ctx, span := tracing.Start(ctx, "SeriesService.GetSeriesByID")
+	defer span.End()
+
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return models.EventSeries{}, err
+	}
+
+	return s.seriesRepository.FindOneByID(ctx, oid)
+}
+
+func (s *seriesService) GetSeriesEvents(ctx context.Context, id string) ([]models.Event, error) {
+	ctx, span := tracing.Start(ctx, "SeriesService.GetSeriesEvents")
+	defer span.End()
+
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.seriesRepository.FindOneByID(ctx, oid)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.eventRepository.Find(ctx, models.Event{
+		SeriesID: oid,
+	})
+}
+
+func (s *seriesService) UpdateSeries(ctx context.Context, id string, name string, venue string, resalePriceCap int, address *models.Address, location *models.GeoPoint) (models.EventSeries, error) {
+	ctx, span := tracing.Start(ctx, "SeriesService.UpdateSeries")
+	defer span.End()
+
+	oid, err := bson.ObjectIDFromHex(id)
+	if err != nil {
+		return models.EventSeries{}, err
+	}
+
+	if name == "" && venue == "" && resalePriceCap == 0 && address == nil && location == nil {
+		return models.EventSeries{}, ErrNoSeriesChanges
+	}
+
+	tx, err := s.mongoClient.StartSession()
+	if err != nil {
+		return models.EventSeries{}, err
+	}
+	defer tx.EndSession(ctx)
+
+	series, err := tx.WithTransaction(ctx, metrics.InstrumentTransaction("update_series", func(txCtx context.Context) (any, error) {
+		series, err := s.seriesRepository.Update(txCtx, models.EventSeries{
+			ID:             oid,
+			Name:           name,
+			Venue:          venue,
+			ResalePriceCap: resalePriceCap,
+			Address:        address,
+			Location:       location,
+		})
+		if err != nil {
+			return models.EventSeries{}, err
+		}
+
+		_, err = s.eventRepository.UpdateSeriesEvents(txCtx, oid, time.Now(), models.Event{
+			Name:           name,
+			Venue:          venue,
+			ResalePriceCap: resalePriceCap,
+			Address:        address,
+			Location:       location,
+		})
+		if err != nil {
+			return models.EventSeries{}, err
+		}
+
+		return series, nil
+	}))
+	if err != nil {
+		return models.EventSeries{}, err
+	}
+
+	return series.(models.EventSeries), nil
+}
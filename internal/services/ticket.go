@@ -5,42 +5,52 @@ import (
 	"errors"
 	"time"
 
+	"github.com/enxg/skyticket/internal/metrics"
 	"github.com/enxg/skyticket/internal/models"
 	"github.com/enxg/skyticket/internal/repositories"
+	"github.com/enxg/skyticket/internal/tracing"
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 )
 
 type TicketService interface {
-	CreateTicket(ctx context.Context, eventID string, seatNumber string, price int) (models.Ticket, error)
+	CreateTicket(ctx context.Context, eventID string, seatNumber string, price int, category string, section string, row string, seat int, capacity int) (models.Ticket, error)
 	GetTicket(ctx context.Context, ticketID string, eventID string) (models.Ticket, error)
 	GetTicketsByEvent(ctx context.Context, eventID string) ([]models.Ticket, error)
-	UpdateTicket(ctx context.Context, ticketID string, eventID string, seatNumber string, price int) (models.Ticket, error)
+	UpdateTicket(ctx context.Context, ticketID string, eventID string, seatNumber string, price int, category string, section string, row string, seat int, capacity int) (models.Ticket, error)
 	DeleteTicket(ctx context.Context, ticketID string, eventID string) error
+	ImportTickets(ctx context.Context, eventID string, tickets []models.Ticket, dryRun bool) ([]models.Ticket, []error, error)
 }
 
 type ticketService struct {
 	ticketRepository      repositories.TicketRepository
 	eventRepository       repositories.EventRepository
 	reservationRepository repositories.ReservationRepository
+	listingRepository     repositories.ListingRepository
 	mongoClient           *mongo.Client
 }
 
 var (
-	ErrEventNotFound   = errors.New("event not found")
-	ErrSeatNumberTaken = errors.New("seat number is already taken")
+	ErrEventNotFound      = errors.New("event not found")
+	ErrSeatNumberTaken    = errors.New("seat number is already taken")
+	ErrCapacityBelowSold  = errors.New("capacity is below the number of places already sold")
+	ErrTicketTypeMismatch = errors.New("field does not apply to this ticket type")
 )
 
-func NewTicketService(ticketRepository repositories.TicketRepository, eventRepository repositories.EventRepository, reservationRepository repositories.ReservationRepository, mongoClient *mongo.Client) TicketService {
+func NewTicketService(ticketRepository repositories.TicketRepository, eventRepository repositories.EventRepository, reservationRepository repositories.ReservationRepository, listingRepository repositories.ListingRepository, mongoClient *mongo.Client) TicketService {
 	return &ticketService{
 		ticketRepository:      ticketRepository,
 		eventRepository:       eventRepository,
 		reservationRepository: reservationRepository,
+		listingRepository:     listingRepository,
 		mongoClient:           mongoClient,
 	}
 }
 
-func (t *ticketService) CreateTicket(ctx context.Context, eventID string, seatNumber string, price int) (models.Ticket, error) {
+func (t *ticketService) CreateTicket(ctx context.Context, eventID string, seatNumber string, price int, category string, section string, row string, seat int, capacity int) (models.Ticket, error) {
+	ctx, span := tracing.Start(ctx, "TicketService.CreateTicket")
+	defer span.End()
+
 	eventOid, err := bson.ObjectIDFromHex(eventID)
 	if err != nil {
 		return models.Ticket{}, err
@@ -58,26 +68,42 @@ func (t *ticketService) CreateTicket(ctx context.Context, eventID string, seatNu
 		return models.Ticket{}, ErrEventAlreadyPassed
 	}
 
-	existingTickets, err := t.ticketRepository.Find(ctx, models.Ticket{
-		EventID:    event.ID,
-		SeatNumber: seatNumber,
-	})
-	if err != nil {
-		return models.Ticket{}, err
-	}
-	if len(existingTickets) > 0 {
-		return models.Ticket{}, ErrSeatNumberTaken
+	if event.Status == models.EventStatusCancelled {
+		return models.Ticket{}, ErrEventCancelled
 	}
 
-	return t.ticketRepository.Create(ctx, models.Ticket{
+	ticket := models.Ticket{
 		EventID:    event.ID,
 		SeatNumber: seatNumber,
 		Price:      price,
 		Status:     models.TicketStatusAvailable,
-	})
+		Category:   category,
+		Section:    section,
+		Row:        row,
+		Seat:       seat,
+	}
+	if capacity > 0 {
+		ticket.Type = models.TicketTypeGeneralAdmission
+		ticket.Capacity = capacity
+		ticket.Remaining = capacity
+	}
+	ticket.Locate()
+
+	ticket, err = t.ticketRepository.Create(ctx, ticket)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return models.Ticket{}, ErrSeatNumberTaken
+		}
+		return models.Ticket{}, err
+	}
+
+	return ticket, refreshSalesStatus(ctx, t.eventRepository, t.ticketRepository, event.ID)
 }
 
 func (t *ticketService) GetTicket(ctx context.Context, ticketID string, eventID string) (models.Ticket, error) {
+	ctx, span := tracing.Start(ctx, "TicketService.GetTicket")
+	defer span.End()
+
 	eventOid, err := bson.ObjectIDFromHex(eventID)
 	if err != nil {
 		return models.Ticket{}, err
@@ -95,6 +121,9 @@ func (t *ticketService) GetTicket(ctx context.Context, ticketID string, eventID
 }
 
 func (t *ticketService) GetTicketsByEvent(ctx context.Context, eventID string) ([]models.Ticket, error) {
+	ctx, span := tracing.Start(ctx, "TicketService.GetTicketsByEvent")
+	defer span.End()
+
 	eventOid, err := bson.ObjectIDFromHex(eventID)
 	if err != nil {
 		return nil, err
@@ -113,7 +142,10 @@ func (t *ticketService) GetTicketsByEvent(ctx context.Context, eventID string) (
 	})
 }
 
-func (t *ticketService) UpdateTicket(ctx context.Context, ticketID string, eventID string, seatNumber string, price int) (models.Ticket, error) {
+func (t *ticketService) UpdateTicket(ctx context.Context, ticketID string, eventID string, seatNumber string, price int, category string, section string, row string, seat int, capacity int) (models.Ticket, error) {
+	ctx, span := tracing.Start(ctx, "TicketService.UpdateTicket")
+	defer span.End()
+
 	eventOid, err := bson.ObjectIDFromHex(eventID)
 	if err != nil {
 		return models.Ticket{}, err
@@ -124,29 +156,68 @@ func (t *ticketService) UpdateTicket(ctx context.Context, ticketID string, event
 		return models.Ticket{}, err
 	}
 
-	if seatNumber != "" {
-		searchSeat, err := t.ticketRepository.FindOne(ctx, models.Ticket{
-			EventID:    eventOid,
-			SeatNumber: seatNumber,
-		})
-		if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+	current, err := t.ticketRepository.FindOne(ctx, models.Ticket{
+		ID:      oid,
+		EventID: eventOid,
+	})
+	if err != nil {
+		return models.Ticket{}, err
+	}
+
+	// Seats only make sense for seated tickets, and a capacity only for
+	// general admission ones.
+	if current.IsGeneralAdmission() {
+		if seatNumber != "" || row != "" || seat != 0 {
+			return models.Ticket{}, ErrTicketTypeMismatch
+		}
+	} else if capacity > 0 {
+		return models.Ticket{}, ErrTicketTypeMismatch
+	}
+
+	if capacity > 0 {
+		resized, err := t.ticketRepository.ResizeCapacity(ctx, eventOid, oid, capacity)
+		if err != nil {
 			return models.Ticket{}, err
 		}
 
-		if searchSeat.ID != oid && searchSeat.SeatNumber == seatNumber {
-			return models.Ticket{}, ErrSeatNumberTaken
+		if !resized {
+			return models.Ticket{}, ErrCapacityBelowSold
 		}
 	}
 
-	return t.ticketRepository.Update(ctx, models.Ticket{
+	ticket := models.Ticket{
 		ID:         oid,
 		EventID:    eventOid,
 		SeatNumber: seatNumber,
 		Price:      price,
-	})
+		Category:   category,
+		Section:    section,
+		Row:        row,
+		Seat:       seat,
+	}
+	if !current.IsGeneralAdmission() {
+		ticket.Locate()
+	}
+
+	ticket, err = t.ticketRepository.Update(ctx, ticket)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return models.Ticket{}, ErrSeatNumberTaken
+		}
+		return models.Ticket{}, err
+	}
+
+	if capacity > 0 {
+		return ticket, refreshSalesStatus(ctx, t.eventRepository, t.ticketRepository, eventOid)
+	}
+
+	return ticket, nil
 }
 
 func (t *ticketService) DeleteTicket(ctx context.Context, ticketID string, eventID string) error {
+	ctx, span := tracing.Start(ctx, "TicketService.DeleteTicket")
+	defer span.End()
+
 	eventOid, err := bson.ObjectIDFromHex(eventID)
 	if err != nil {
 		return err
@@ -161,8 +232,9 @@ func (t *ticketService) DeleteTicket(ctx context.Context, ticketID string, event
 	if err != nil {
 		return err
 	}
+	defer tx.EndSession(ctx)
 
-	_, err = tx.WithTransaction(ctx, func(txCtx context.Context) (any, error) {
+	_, err = tx.WithTransaction(ctx, metrics.InstrumentTransaction("delete_ticket", func(txCtx context.Context) (any, error) {
 		err := t.ticketRepository.Delete(txCtx, models.Ticket{
 			ID:      oid,
 			EventID: eventOid,
@@ -171,10 +243,110 @@ func (t *ticketService) DeleteTicket(ctx context.Context, ticketID string, event
 			return nil, err
 		}
 
-		return nil, t.reservationRepository.DeleteMany(txCtx, models.Reservation{
+		err = t.reservationRepository.DeleteMany(txCtx, models.Reservation{
 			TicketID: oid,
 		})
-	})
+		if err != nil {
+			return nil, err
+		}
+
+		err = t.listingRepository.DeleteMany(txCtx, models.Listing{
+			TicketID: oid,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, refreshSalesStatus(txCtx, t.eventRepository, t.ticketRepository, eventOid)
+	}))
 
 	return err
 }
+
+// ImportTickets creates all given tickets in a single transaction. Problems
+// with individual tickets are reported in the returned slice at the same index
+// as the ticket, and nothing is written unless every ticket is valid. With
+// dryRun set, the tickets are checked but never written.
+func (t *ticketService) ImportTickets(ctx context.Context, eventID string, tickets []models.Ticket, dryRun bool) ([]models.Ticket, []error, error) {
+	ctx, span := tracing.Start(ctx, "TicketService.ImportTickets")
+	defer span.End()
+
+	eventOid, err := bson.ObjectIDFromHex(eventID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	event, err := t.eventRepository.FindOneByID(ctx, eventOid)
+	if err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, nil, ErrEventNotFound
+		}
+		return nil, nil, err
+	}
+
+	if time.Now().After(event.Date) {
+		return nil, nil, ErrEventAlreadyPassed
+	}
+
+	if event.Status == models.EventStatusCancelled {
+		return nil, nil, ErrEventCancelled
+	}
+
+	seatNumbers := make([]string, len(tickets))
+	for i, ticket := range tickets {
+		seatNumbers[i] = ticket.SeatNumber
+	}
+
+	existing, err := t.ticketRepository.FindBySeatNumbers(ctx, eventOid, seatNumbers)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	taken := make(map[string]struct{}, len(existing)+len(tickets))
+	for _, ticket := range existing {
+		taken[ticket.SeatNumber] = struct{}{}
+	}
+
+	rowErrs := make([]error, len(tickets))
+	valid := true
+	for i := range tickets {
+		if _, ok := taken[tickets[i].SeatNumber]; ok {
+			rowErrs[i] = ErrSeatNumberTaken
+			valid = false
+			continue
+		}
+		taken[tickets[i].SeatNumber] = struct{}{}
+
+		tickets[i].EventID = eventOid
+		tickets[i].Status = models.TicketStatusAvailable
+		tickets[i].Locate()
+	}
+
+	if !valid || dryRun || len(tickets) == 0 {
+		return nil, rowErrs, nil
+	}
+
+	tx, err := t.mongoClient.StartSession()
+	if err != nil {
+		return nil, nil, err
+	}
+	defer tx.EndSession(ctx)
+
+	created, err := tx.WithTransaction(ctx, metrics.InstrumentTransaction("import_tickets", func(txCtx context.Context) (any, error) {
+		created, err := t.ticketRepository.CreateMany(txCtx, tickets)
+		if err != nil {
+			return nil, err
+		}
+
+		return created, refreshSalesStatus(txCtx, t.eventRepository, t.ticketRepository, eventOid)
+	}))
+	if err != nil {
+		// A seat was taken by a concurrent request after the check above.
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, nil, ErrSeatNumberTaken
+		}
+		return nil, nil, err
+	}
+
+	return created.([]models.Ticket), rowErrs, nil
+}
@@ -0,0 +1,192 @@
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Rule is a subset of the RFC 5545 recurrence rule. FREQ (DAILY, WEEKLY or
+// MONTHLY), INTERVAL, COUNT, UNTIL and BYDAY (WEEKLY only) are supported.
+type Rule struct {
+	Freq     Frequency
+	Interval int
+	Count    int
+	Until    time.Time
+	ByDay    []time.Weekday
+}
+
+type Frequency string
+
+const (
+	Daily   Frequency = "DAILY"
+	Weekly  Frequency = "WEEKLY"
+	Monthly Frequency = "MONTHLY"
+)
+
+var (
+	ErrUnbounded     = errors.New("rrule: either COUNT or UNTIL is required")
+	ErrTooManyEvents = errors.New("rrule: rule produces too many occurrences")
+)
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+func Parse(s string) (Rule, error) {
+	r := Rule{Interval: 1}
+
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	for part := range strings.SplitSeq(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return Rule{}, fmt.Errorf("rrule: malformed part %q", part)
+		}
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			r.Freq = Frequency(strings.ToUpper(value))
+			if r.Freq != Daily && r.Freq != Weekly && r.Freq != Monthly {
+				return Rule{}, fmt.Errorf("rrule: unsupported FREQ %q", value)
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return Rule{}, fmt.Errorf("rrule: invalid INTERVAL %q", value)
+			}
+			r.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return Rule{}, fmt.Errorf("rrule: invalid COUNT %q", value)
+			}
+			r.Count = n
+		case "UNTIL":
+			t, err := parseUntil(value)
+			if err != nil {
+				return Rule{}, fmt.Errorf("rrule: invalid UNTIL %q", value)
+			}
+			r.Until = t
+		case "BYDAY":
+			for day := range strings.SplitSeq(value, ",") {
+				wd, ok := weekdays[strings.ToUpper(day)]
+				if !ok {
+					return Rule{}, fmt.Errorf("rrule: invalid BYDAY %q", day)
+				}
+				r.ByDay = append(r.ByDay, wd)
+			}
+		default:
+			return Rule{}, fmt.Errorf("rrule: unsupported part %q", key)
+		}
+	}
+
+	if r.Freq == "" {
+		return Rule{}, errors.New("rrule: FREQ is required")
+	}
+
+	if len(r.ByDay) > 0 && r.Freq != Weekly {
+		return Rule{}, errors.New("rrule: BYDAY is only supported with FREQ=WEEKLY")
+	}
+
+	if r.Count == 0 && r.Until.IsZero() {
+		return Rule{}, ErrUnbounded
+	}
+
+	return r, nil
+}
+
+func parseUntil(value string) (time.Time, error) {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse("20060102", value)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	// A date-only UNTIL includes the whole day.
+	return t.Add(24*time.Hour - time.Nanosecond), nil
+}
+
+// Occurrences expands the rule starting at dtstart, keeping the wall-clock time
+// in dtstart's location. ErrTooManyEvents is returned if the rule produces more
+// than limit occurrences.
+func (r Rule) Occurrences(dtstart time.Time, limit int) ([]time.Time, error) {
+	var out []time.Time
+
+	add := func(t time.Time) (bool, error) {
+		if !r.Until.IsZero() && t.After(r.Until) {
+			return false, nil
+		}
+		if r.Count > 0 && len(out) >= r.Count {
+			return false, nil
+		}
+		if len(out) >= limit {
+			return false, ErrTooManyEvents
+		}
+		out = append(out, t)
+		return true, nil
+	}
+
+	switch r.Freq {
+	case Daily:
+		for i := 0; ; i++ {
+			ok, err := add(dtstart.AddDate(0, 0, i*r.Interval))
+			if !ok {
+				return out, err
+			}
+		}
+	case Weekly:
+		days := r.ByDay
+		if len(days) == 0 {
+			days = []time.Weekday{dtstart.Weekday()}
+		}
+		days = slices.Clone(days)
+		slices.SortFunc(days, func(a, b time.Weekday) int {
+			return mondayIndex(a) - mondayIndex(b)
+		})
+
+		weekStart := dtstart.AddDate(0, 0, -mondayIndex(dtstart.Weekday()))
+		for i := 0; ; i++ {
+			week := weekStart.AddDate(0, 0, 7*i*r.Interval)
+			for _, wd := range days {
+				t := week.AddDate(0, 0, mondayIndex(wd))
+				if t.Before(dtstart) {
+					continue
+				}
+				ok, err := add(t)
+				if !ok {
+					return out, err
+				}
+			}
+		}
+	case Monthly:
+		for i := 0; ; i++ {
+			t := dtstart.AddDate(0, i*r.Interval, 0)
+			// Months without the start day (e.g. the 31st) are skipped, as in RFC 5545.
+			if t.Day() != dtstart.Day() {
+				continue
+			}
+			ok, err := add(t)
+			if !ok {
+				return out, err
+			}
+		}
+	}
+
+	return out, nil
+}
+
+func mondayIndex(wd time.Weekday) int {
+	return (int(wd) + 6) % 7
+}
@@ -0,0 +1,236 @@
+package rrule
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		in      string
+		want    Rule
+		wantErr error
+	}{
+		{
+			name: "prefix and lowercase",
+			in:   "RRULE:freq=weekly;byday=mo,we;count=4",
+			want: Rule{Freq: Weekly, Interval: 1, Count: 4, ByDay: []time.Weekday{time.Monday, time.Wednesday}},
+		},
+		{
+			name: "interval",
+			in:   "FREQ=DAILY;INTERVAL=3;COUNT=2",
+			want: Rule{Freq: Daily, Interval: 3, Count: 2},
+		},
+		{
+			name: "until with time",
+			in:   "FREQ=MONTHLY;UNTIL=20260301T120000Z",
+			want: Rule{Freq: Monthly, Interval: 1, Until: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)},
+		},
+		{
+			name: "date-only until covers the whole day",
+			in:   "FREQ=DAILY;UNTIL=20260301",
+			want: Rule{Freq: Daily, Interval: 1, Until: time.Date(2026, 3, 1, 23, 59, 59, 999999999, time.UTC)},
+		},
+		{name: "unbounded", in: "FREQ=DAILY", wantErr: ErrUnbounded},
+		{name: "missing freq", in: "COUNT=3", wantErr: errAny},
+		{name: "unsupported freq", in: "FREQ=YEARLY;COUNT=3", wantErr: errAny},
+		{name: "zero interval", in: "FREQ=DAILY;INTERVAL=0;COUNT=3", wantErr: errAny},
+		{name: "zero count", in: "FREQ=DAILY;COUNT=0", wantErr: errAny},
+		{name: "bad until", in: "FREQ=DAILY;UNTIL=tomorrow", wantErr: errAny},
+		{name: "bad weekday", in: "FREQ=WEEKLY;BYDAY=XX;COUNT=3", wantErr: errAny},
+		{name: "byday without weekly", in: "FREQ=DAILY;BYDAY=MO;COUNT=3", wantErr: errAny},
+		{name: "unsupported part", in: "FREQ=DAILY;COUNT=3;BYMONTH=1", wantErr: errAny},
+		{name: "malformed part", in: "FREQ=DAILY;COUNT", wantErr: errAny},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.in)
+			if tt.wantErr != nil {
+				if err == nil {
+					t.Fatalf("Parse(%q) = %+v, want error", tt.in, got)
+				}
+				if tt.wantErr != errAny && !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Parse(%q) error = %v, want %v", tt.in, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.in, err)
+			}
+			if got.Freq != tt.want.Freq || got.Interval != tt.want.Interval || got.Count != tt.want.Count ||
+				!got.Until.Equal(tt.want.Until) || !slices.Equal(got.ByDay, tt.want.ByDay) {
+				t.Fatalf("Parse(%q) = %+v, want %+v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+// errAny marks cases where any error is expected.
+var errAny = errors.New("any error")
+
+func TestOccurrences(t *testing.T) {
+	istanbul, err := time.LoadLocation("Europe/Istanbul")
+	if err != nil {
+		t.Skip("timezone database not available")
+	}
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("timezone database not available")
+	}
+
+	tests := []struct {
+		name    string
+		rule    string
+		start   time.Time
+		limit   int
+		want    []string
+		wantErr error
+	}{
+		{
+			name:  "daily count",
+			rule:  "FREQ=DAILY;COUNT=3",
+			start: time.Date(2026, 1, 30, 20, 0, 0, 0, istanbul),
+			want:  []string{"2026-01-30 20:00", "2026-01-31 20:00", "2026-02-01 20:00"},
+		},
+		{
+			name:  "daily interval until",
+			rule:  "FREQ=DAILY;INTERVAL=2;UNTIL=20260105",
+			start: time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC),
+			want:  []string{"2026-01-01 09:00", "2026-01-03 09:00", "2026-01-05 09:00"},
+		},
+		{
+			name:  "until before start",
+			rule:  "FREQ=DAILY;UNTIL=20251231",
+			start: time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC),
+			want:  nil,
+		},
+		{
+			name:  "daily keeps wall clock across dst",
+			rule:  "FREQ=DAILY;COUNT=3",
+			start: time.Date(2026, 3, 28, 20, 0, 0, 0, berlin),
+			want:  []string{"2026-03-28 20:00", "2026-03-29 20:00", "2026-03-30 20:00"},
+		},
+		{
+			name:  "weekly defaults to the start weekday",
+			rule:  "FREQ=WEEKLY;COUNT=3",
+			start: time.Date(2026, 1, 7, 19, 30, 0, 0, time.UTC), // Wednesday
+			want:  []string{"2026-01-07 19:30", "2026-01-14 19:30", "2026-01-21 19:30"},
+		},
+		{
+			name:  "weekly byday skips days before the start",
+			rule:  "FREQ=WEEKLY;BYDAY=MO,FR;COUNT=4",
+			start: time.Date(2026, 1, 7, 19, 0, 0, 0, time.UTC), // Wednesday
+			want:  []string{"2026-01-09 19:00", "2026-01-12 19:00", "2026-01-16 19:00", "2026-01-19 19:00"},
+		},
+		{
+			name:  "weekly byday is ordered from monday",
+			rule:  "FREQ=WEEKLY;BYDAY=SU,MO;COUNT=3",
+			start: time.Date(2026, 1, 5, 18, 0, 0, 0, time.UTC), // Monday
+			want:  []string{"2026-01-05 18:00", "2026-01-11 18:00", "2026-01-12 18:00"},
+		},
+		{
+			name:  "weekly starting on sunday stays in its week",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,SU;COUNT=3",
+			start: time.Date(2026, 1, 11, 18, 0, 0, 0, time.UTC), // Sunday
+			want:  []string{"2026-01-11 18:00", "2026-01-19 18:00", "2026-01-25 18:00"},
+		},
+		{
+			name:  "monthly skips months without the day",
+			rule:  "FREQ=MONTHLY;COUNT=4",
+			start: time.Date(2026, 1, 31, 20, 0, 0, 0, time.UTC),
+			want:  []string{"2026-01-31 20:00", "2026-03-31 20:00", "2026-05-31 20:00", "2026-07-31 20:00"},
+		},
+		{
+			name:  "monthly interval",
+			rule:  "FREQ=MONTHLY;INTERVAL=3;UNTIL=20261231",
+			start: time.Date(2026, 2, 15, 20, 0, 0, 0, time.UTC),
+			want:  []string{"2026-02-15 20:00", "2026-05-15 20:00", "2026-08-15 20:00", "2026-11-15 20:00"},
+		},
+		{
+			name:  "monthly leap day",
+			rule:  "FREQ=MONTHLY;INTERVAL=12;COUNT=2",
+			start: time.Date(2024, 2, 29, 20, 0, 0, 0, time.UTC),
+			want:  []string{"2024-02-29 20:00", "2028-02-29 20:00"},
+		},
+		{
+			name:  "count equal to the limit",
+			rule:  "FREQ=DAILY;COUNT=3",
+			start: time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC),
+			limit: 3,
+			want:  []string{"2026-01-01 09:00", "2026-01-02 09:00", "2026-01-03 09:00"},
+		},
+		{
+			name:    "count above the limit",
+			rule:    "FREQ=DAILY;COUNT=4",
+			start:   time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC),
+			limit:   3,
+			wantErr: ErrTooManyEvents,
+		},
+		{
+			name:  "until reaching exactly the limit",
+			rule:  "FREQ=DAILY;UNTIL=20260103",
+			start: time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC),
+			limit: 3,
+			want:  []string{"2026-01-01 09:00", "2026-01-02 09:00", "2026-01-03 09:00"},
+		},
+		{
+			name:    "until beyond the limit",
+			rule:    "FREQ=WEEKLY;BYDAY=MO,TU,WE;UNTIL=20261231",
+			start:   time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC),
+			limit:   10,
+			wantErr: ErrTooManyEvents,
+		},
+		{
+			name:  "count stops before until",
+			rule:  "FREQ=DAILY;COUNT=2;UNTIL=20261231",
+			start: time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC),
+			want:  []string{"2026-01-01 09:00", "2026-01-02 09:00"},
+		},
+		{
+			name:  "until stops before count",
+			rule:  "FREQ=DAILY;COUNT=10;UNTIL=20260102",
+			start: time.Date(2026, 1, 1, 9, 0, 0, 0, time.UTC),
+			want:  []string{"2026-01-01 09:00", "2026-01-02 09:00"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.rule)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.rule, err)
+			}
+
+			limit := tt.limit
+			if limit == 0 {
+				limit = 100
+			}
+
+			got, err := rule.Occurrences(tt.start, limit)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Occurrences() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Occurrences() error = %v", err)
+			}
+
+			formatted := make([]string, len(got))
+			for i, o := range got {
+				if o.Location() != tt.start.Location() {
+					t.Errorf("occurrence %d is in %v, want %v", i, o.Location(), tt.start.Location())
+				}
+				formatted[i] = o.Format("2006-01-02 15:04")
+			}
+			if !slices.Equal(formatted, tt.want) && (len(formatted) != 0 || len(tt.want) != 0) {
+				t.Fatalf("Occurrences() = %v, want %v", formatted, tt.want)
+			}
+		})
+	}
+}
@@ -30,6 +30,9 @@ func NewStructValidator() StructValidator {
 
 	vld.RegisterTagNameFunc(func(fld reflect.StructField) string {
 		name := strings.SplitN(fld.Tag.Get("json"), ",", 2)[0]
+		if name == "" {
+			name = strings.SplitN(fld.Tag.Get("query"), ",", 2)[0]
+		}
 		if name == "-" {
 			return ""
 		}
@@ -76,7 +79,27 @@ func validationErrorToText(e validator.FieldError) string {
 	case "required":
 		return fmt.Sprintf("%s is required.", e.Field())
 	case "datetime":
+		if e.Param() == "2006-01-02" {
+			return fmt.Sprintf("%s must be a date in YYYY-MM-DD format.", e.Field())
+		}
+		if e.Param() == "2006-01" {
+			return fmt.Sprintf("%s must be a month in YYYY-MM format.", e.Field())
+		}
 		return fmt.Sprintf("%s must be in RFC3339 format.", e.Field())
+	case "datetime=2006-01-02T15:04:05Z07:00|datetime=2006-01-02T15:04:05":
+		return fmt.Sprintf("%s must be in RFC3339 format, or a local date-time without UTC offset.", e.Field())
+	case "timezone":
+		return fmt.Sprintf("%s must be a valid IANA time zone.", e.Field())
+	case "latlong":
+		return fmt.Sprintf("%s must be a coordinate pair in lat,lng format.", e.Field())
+	case "latitude":
+		return fmt.Sprintf("%s must be a valid latitude.", e.Field())
+	case "longitude":
+		return fmt.Sprintf("%s must be a valid longitude.", e.Field())
+	case "iso3166_1_alpha2":
+		return fmt.Sprintf("%s must be a two-letter ISO 3166-1 country code.", e.Field())
+	case "oneof":
+		return fmt.Sprintf("%s must be one of: %s.", e.Field(), strings.ReplaceAll(e.Param(), " ", ", "))
 	case "gt":
 		switch e.Kind() {
 		case reflect.String:
@@ -91,6 +114,8 @@ func validationErrorToText(e validator.FieldError) string {
 		default:
 			return fmt.Sprintf("%s must be less than %s.", e.Field(), e.Param())
 		}
+	case "max":
+		return fmt.Sprintf("%s must contain at most %s items.", e.Field(), e.Param())
 	default:
 		return fmt.Sprintf("%s is invalid.", e.Field())
 	}